@@ -15,9 +15,31 @@ type Config struct {
 	DBMaxConnIdleTime   time.Duration `envconfig:"DB_MAX_CONN_IDLE_TIME" default:"30m"`
 	DBHealthcheckPeriod time.Duration `envconfig:"DB_HEALTHCHECK_PERIOD" default:"1m"`
 
+	// ReadDSN lists comma-separated read replicas serving article listings.
+	ReadDSN               []string      `envconfig:"READ_DSN"`
+	ReadHealthcheckPeriod time.Duration `envconfig:"READ_HEALTHCHECK_PERIOD" default:"5s"`
+	ReadStickyWindow      time.Duration `envconfig:"READ_STICKY_WINDOW" default:"5s"`
+
+	// SQLiteMigrations is where the migrations of a sqlite:// DSN are read
+	// from; they are applied at startup.
+	SQLiteMigrations string `envconfig:"SQLITE_MIGRATIONS" default:"file://migrations/sqlite"`
+
 	HTTPReadTimeout  time.Duration `envconfig:"HTTP_READ_TIMEOUT" default:"30s"`
 	HTTPWriteTimeout time.Duration `envconfig:"HTTP_WRITE_TIMEOUT" default:"30s"`
 	HTTPIdleTimeout  time.Duration `envconfig:"HTTP_IDLE_TIMEOUT" default:"120s"`
+
+	AdminToken string `envconfig:"ADMIN_TOKEN"`
+
+	// PublicBaseURL is where clients reach the API; feed links are built
+	// from it.
+	PublicBaseURL string `envconfig:"PUBLIC_BASE_URL" default:"http://localhost:8080"`
+
+	SuggestCacheSize int           `envconfig:"SUGGEST_CACHE_SIZE" default:"1024"`
+	SuggestCacheTTL  time.Duration `envconfig:"SUGGEST_CACHE_TTL" default:"30s"`
+
+	// ArticleCacheSize of 0 disables caching of article listings.
+	ArticleCacheSize int           `envconfig:"ARTICLE_CACHE_SIZE" default:"256"`
+	ArticleCacheTTL  time.Duration `envconfig:"ARTICLE_CACHE_TTL" default:"10s"`
 }
 
 func getConfig() Config {
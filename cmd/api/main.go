@@ -8,10 +8,8 @@ import (
 	"time"
 
 	"github.com/ariefsibuea/articles-feed/internal/api/handler"
-	"github.com/ariefsibuea/articles-feed/internal/api/repository"
 	"github.com/ariefsibuea/articles-feed/internal/api/usecase"
 
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
 )
@@ -31,26 +29,16 @@ func main() {
 	// customize error handler
 	e.HTTPErrorHandler = handler.ErrorHandler()
 
-	poolConfig, err := pgxpool.ParseConfig(cfg.DSN)
-	if err != nil {
-		e.Logger.Fatalf("unable to parse database config: %v", err)
+	// keep a client that just wrote on the primary while replicas catch up
+	if len(cfg.ReadDSN) > 0 {
+		e.Use(handler.ReadYourWrites(cfg.ReadStickyWindow))
 	}
 
-	poolConfig.MaxConns = cfg.DBMaxConns
-	poolConfig.MaxConnLifetime = cfg.DBMaxConnLifetime
-	poolConfig.MaxConnIdleTime = cfg.DBMaxConnIdleTime
-	poolConfig.MinConns = cfg.DBMinConns
-	poolConfig.HealthCheckPeriod = cfg.DBHealthcheckPeriod
-
-	dbpool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+	repos, closeDB, err := initRepositories(cfg)
 	if err != nil {
 		e.Logger.Fatalf("unable to connect to database: %v", err)
 	}
-	defer dbpool.Close()
-
-	if err := dbpool.Ping(context.Background()); err != nil {
-		e.Logger.Fatalf("unable to ping database: %v", err)
-	}
+	defer closeDB()
 
 	// healthcheck endpoint
 	e.GET("/health", func(c echo.Context) error {
@@ -59,15 +47,19 @@ func main() {
 		})
 	})
 
-	// init repositories
-	articleRepository := repository.InitArticleRepository(dbpool)
-	authorRepository := repository.InitAuthorRepository(dbpool)
-
 	// init usecase
-	articleUseCase := usecase.InitArticleUseCase(articleRepository, authorRepository)
+	articleListCache := usecase.InitLRUArticleListCache(cfg.ArticleCacheSize, cfg.ArticleCacheTTL)
+	articleUseCase := usecase.InitArticleUseCase(repos.article, repos.author, repos.transactor, &articleListCache)
+	tagUseCase := usecase.InitTagUseCase(repos.tag)
+	authorUseCase := usecase.InitAuthorUseCase(repos.author, repos.article)
+	suggestionUseCase := usecase.InitSuggestionUseCase(repos.article, repos.author, cfg.SuggestCacheSize, cfg.SuggestCacheTTL)
 
 	// init handler
-	handler.InitArticleHandler(e, articleUseCase)
+	handler.InitArticleHandler(e, articleUseCase, cfg.AdminToken, cfg.PublicBaseURL)
+	handler.InitTagHandler(e, tagUseCase)
+	handler.InitSuggestionHandler(e, suggestionUseCase)
+	handler.InitFeedHandler(e, articleUseCase, cfg.PublicBaseURL)
+	handler.InitAuthorHandler(e, authorUseCase, cfg.PublicBaseURL)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer stop()
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/repository"
+	"github.com/ariefsibuea/articles-feed/internal/api/repository/sqlite"
+	"github.com/ariefsibuea/articles-feed/internal/api/usecase"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// repositories holds the repositories of the storage backend in use.
+type repositories struct {
+	article    usecase.ArticleRepository
+	author     usecase.AuthorRepository
+	tag        usecase.TagRepository
+	transactor usecase.Transactor
+}
+
+// initRepositories opens the database the DSN points at, picking the backend
+// by its scheme. The returned func closes the database.
+func initRepositories(cfg Config) (repositories, func(), error) {
+	if sqlite.IsDSN(cfg.DSN) {
+		return initSQLiteRepositories(cfg)
+	}
+	return initPostgresRepositories(cfg)
+}
+
+func initPostgresRepositories(cfg Config) (repositories, func(), error) {
+	dbpool, err := newPool(cfg, cfg.DSN)
+	if err != nil {
+		return repositories{}, nil, err
+	}
+
+	if err := dbpool.Ping(context.Background()); err != nil {
+		dbpool.Close()
+		return repositories{}, nil, err
+	}
+
+	// replicas that cannot be reached yet are left to the health checks
+	replicaPools := make([]*pgxpool.Pool, 0, len(cfg.ReadDSN))
+	for _, dsn := range cfg.ReadDSN {
+		pool, err := newPool(cfg, dsn)
+		if err != nil {
+			closePools(dbpool, replicaPools)
+			return repositories{}, nil, err
+		}
+		replicaPools = append(replicaPools, pool)
+	}
+
+	replicas := repository.InitReplicas(dbpool, replicaPools)
+
+	// replicas that are down at startup are skipped from the first request
+	healthCtx, stopHealthChecks := context.WithCancel(context.Background())
+	if len(replicaPools) > 0 {
+		replicas.CheckHealth(healthCtx, cfg.ReadHealthcheckPeriod)
+		go replicas.WatchHealth(healthCtx, cfg.ReadHealthcheckPeriod)
+	}
+
+	articleRepository := repository.InitArticleRepository(dbpool, replicas)
+	authorRepository := repository.InitAuthorRepository(dbpool)
+	tagRepository := repository.InitTagRepository(dbpool)
+	transactor := repository.InitTransactor(dbpool)
+
+	return repositories{
+		article:    &articleRepository,
+		author:     &authorRepository,
+		tag:        &tagRepository,
+		transactor: &transactor,
+	}, func() {
+		stopHealthChecks()
+		closePools(dbpool, replicaPools)
+	}, nil
+}
+
+func newPool(cfg Config, dsn string) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	poolConfig.MaxConns = cfg.DBMaxConns
+	poolConfig.MaxConnLifetime = cfg.DBMaxConnLifetime
+	poolConfig.MaxConnIdleTime = cfg.DBMaxConnIdleTime
+	poolConfig.MinConns = cfg.DBMinConns
+	poolConfig.HealthCheckPeriod = cfg.DBHealthcheckPeriod
+	poolConfig.AfterConnect = repository.SetSearchPath
+
+	return pgxpool.NewWithConfig(context.Background(), poolConfig)
+}
+
+func closePools(primary *pgxpool.Pool, replicas []*pgxpool.Pool) {
+	for _, pool := range replicas {
+		pool.Close()
+	}
+	primary.Close()
+}
+
+// initSQLiteRepositories also applies the SQLite migrations, as a SQLite
+// database is usually created by the app itself.
+func initSQLiteRepositories(cfg Config) (repositories, func(), error) {
+	if len(cfg.ReadDSN) > 0 {
+		return repositories{}, nil, errors.New("READ_DSN is only supported with postgres")
+	}
+
+	db, err := sqlite.Open(cfg.DSN)
+	if err != nil {
+		return repositories{}, nil, err
+	}
+
+	if err := sqlite.Migrate(db, cfg.SQLiteMigrations); err != nil {
+		db.Close()
+		return repositories{}, nil, err
+	}
+
+	articleRepository := sqlite.InitArticleRepository(db)
+	authorRepository := sqlite.InitAuthorRepository(db)
+	tagRepository := sqlite.InitTagRepository(db)
+	transactor := sqlite.InitTransactor(db)
+
+	return repositories{
+		article:    &articleRepository,
+		author:     &authorRepository,
+		tag:        &tagRepository,
+		transactor: &transactor,
+	}, func() { db.Close() }, nil
+}
@@ -1,6 +1,27 @@
 package domain
 
-import "time"
+import (
+	"html"
+	"strings"
+	"time"
+)
+
+type ArticleStatus string
+
+const (
+	ArticleStatusDraft     ArticleStatus = "draft"
+	ArticleStatusScheduled ArticleStatus = "scheduled"
+	ArticleStatusPublished ArticleStatus = "published"
+	ArticleStatusArchived  ArticleStatus = "archived"
+)
+
+func (s ArticleStatus) IsValid() bool {
+	switch s {
+	case ArticleStatusDraft, ArticleStatusScheduled, ArticleStatusPublished, ArticleStatusArchived:
+		return true
+	}
+	return false
+}
 
 type Article struct {
 	UUID       string
@@ -8,7 +29,26 @@ type Article struct {
 	AuthorName string
 	Title      string
 	Body       string
+	Status     ArticleStatus
+	PublishAt  time.Time
+	Language   string
+	Tags       []string
+	Version    int32
 	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  time.Time
+
+	// set only when a search asked for highlighting
+	HighlightedTitle string
+	Snippet          string
+}
+
+// ArticlePatch holds the fields of a partial update. A nil field is left
+// untouched.
+type ArticlePatch struct {
+	Title      *string
+	AuthorName *string
+	Body       *string
 }
 
 type ArticleList struct {
@@ -16,11 +56,139 @@ type ArticleList struct {
 	Page       int32
 	PageSize   int32
 	TotalItems int32
+	NextCursor *ArticleCursor
+	PrevCursor *ArticleCursor
+	Facets     map[ArticleFacet][]FacetBucket
+
+	// Cached is set when the list was served from the listing cache.
+	Cached bool
+	// LastModified is when an article matching the filter was last created,
+	// edited, deleted, moved to another status or published, whether it is
+	// still listed or not, as any of those may change the list.
+	LastModified time.Time
+}
+
+type ArticleFacet string
+
+const (
+	ArticleFacetAuthor ArticleFacet = "author"
+	ArticleFacetMonth  ArticleFacet = "month"
+	ArticleFacetTag    ArticleFacet = "tag"
+)
+
+func (f ArticleFacet) IsValid() bool {
+	switch f {
+	case ArticleFacetAuthor, ArticleFacetMonth, ArticleFacetTag:
+		return true
+	}
+	return false
+}
+
+// FacetBucket is the number of matching articles sharing a facet value, such
+// as an author name or a "2006-01" month.
+type FacetBucket struct {
+	Value string
+	Count int32
+}
+
+type ArticleSort string
+
+const (
+	ArticleSortCreatedAtDesc ArticleSort = "-createdAt"
+	ArticleSortCreatedAtAsc  ArticleSort = "createdAt"
+	ArticleSortTitleAsc      ArticleSort = "title"
+	ArticleSortTitleDesc     ArticleSort = "-title"
+	ArticleSortAuthorAsc     ArticleSort = "author"
+	ArticleSortAuthorDesc    ArticleSort = "-author"
+	ArticleSortRelevance     ArticleSort = "relevance"
+	ArticleSortSimilarity    ArticleSort = "similarity"
+)
+
+const DefaultArticleSort = ArticleSortCreatedAtDesc
+
+func (s ArticleSort) IsValid() bool {
+	switch s {
+	case ArticleSortCreatedAtDesc, ArticleSortCreatedAtAsc, ArticleSortTitleAsc, ArticleSortTitleDesc,
+		ArticleSortAuthorAsc, ArticleSortAuthorDesc, ArticleSortRelevance, ArticleSortSimilarity:
+		return true
+	}
+	return false
+}
+
+type SearchMode string
+
+const (
+	SearchModePlain     SearchMode = "plain"
+	SearchModeWebsearch SearchMode = "websearch"
+	SearchModePrefix    SearchMode = "prefix"
+)
+
+func (m SearchMode) IsValid() bool {
+	switch m {
+	case SearchModePlain, SearchModeWebsearch, SearchModePrefix:
+		return true
+	}
+	return false
+}
+
+// ArticleCursor marks a position in the article listing by the sort key of
+// the article next to it. Backward cursors page towards newer articles.
+type ArticleCursor struct {
+	CreatedAt time.Time
+	UUID      string
+	Backward  bool
 }
 
 type ArticleFilter struct {
 	Page       int32
 	PageSize   int32
 	Query      string
+	SearchMode SearchMode
+	Language   string
+	Fuzzy      bool
 	AuthorName string
+	AuthorUUID string
+	Tags       []string
+	TagMode    TagMatchMode
+	Cursor     *ArticleCursor
+	SkipCount  bool
+	Sort       ArticleSort
+
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+
+	Highlight *HighlightOptions
+	Facets    []ArticleFacet
+
+	// FuzzyThreshold is the minimum word similarity, between 0 and 1, of a
+	// fuzzy match.
+	FuzzyThreshold float64
+
+	IncludeDeleted bool
+}
+
+// HighlightOptions controls how search matches are marked in highlighted
+// titles and snippets.
+type HighlightOptions struct {
+	StartSel     string
+	StopSel      string
+	MaxFragments int32
+}
+
+// HighlightStartMark and HighlightStopMark are what repositories ask the
+// database to wrap matches in, in place of StartSel and StopSel, so that the
+// text around them can still be escaped by Mark.
+const (
+	HighlightStartMark = "\uE000"
+	HighlightStopMark  = "\uE001"
+)
+
+// Mark HTML-escapes text highlighted with HighlightStartMark and
+// HighlightStopMark, and swaps those for StartSel and StopSel. Only the
+// markers are left unescaped, as stored titles and bodies may hold markup.
+func (o *HighlightOptions) Mark(text string) string {
+	return strings.NewReplacer(
+		HighlightStartMark, o.StartSel,
+		HighlightStopMark, o.StopSel,
+	).Replace(html.EscapeString(text))
 }
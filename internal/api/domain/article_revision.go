@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+type ArticleRevision struct {
+	ArticleUUID string
+	Revision    int32
+	AuthorUUID  string
+	AuthorName  string
+	Title       string
+	Body        string
+	CreatedAt   time.Time
+}
+
+type ArticleRevisionDiff struct {
+	ArticleUUID  string
+	FromRevision int32
+	ToRevision   int32
+	Diff         string
+}
@@ -1,6 +1,25 @@
 package domain
 
+import "time"
+
 type Author struct {
 	UUID string
 	Name string
+
+	// ArticleCount and LatestArticleAt only cover listed articles. A zero
+	// LatestArticleAt means the author has none.
+	ArticleCount    int32
+	LatestArticleAt time.Time
+}
+
+type AuthorList struct {
+	Authors    []Author
+	Page       int32
+	PageSize   int32
+	TotalItems int32
+}
+
+type AuthorFilter struct {
+	Page     int32
+	PageSize int32
 }
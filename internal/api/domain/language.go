@@ -0,0 +1,26 @@
+package domain
+
+import "strings"
+
+const (
+	LanguageEnglish    = "en"
+	LanguageIndonesian = "id"
+	// LanguageSimple is used for any language without its own stemming.
+	LanguageSimple = "simple"
+)
+
+var languageAliases = map[string]string{
+	"en":         LanguageEnglish,
+	"english":    LanguageEnglish,
+	"id":         LanguageIndonesian,
+	"indonesian": LanguageIndonesian,
+}
+
+// NormalizeLanguage maps a language code or name to a supported language,
+// falling back to LanguageSimple for unknown languages.
+func NormalizeLanguage(language string) string {
+	if normalized, ok := languageAliases[strings.ToLower(strings.TrimSpace(language))]; ok {
+		return normalized
+	}
+	return LanguageSimple
+}
@@ -0,0 +1,13 @@
+package domain
+
+type SuggestionType string
+
+const (
+	SuggestionTypeTitle  SuggestionType = "title"
+	SuggestionTypeAuthor SuggestionType = "author"
+)
+
+type Suggestion struct {
+	ID    string
+	Value string
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "strings"
+
+type Tag struct {
+	Name         string
+	ArticleCount int32
+}
+
+type TagMatchMode string
+
+const (
+	TagMatchAny TagMatchMode = "any"
+	TagMatchAll TagMatchMode = "all"
+)
+
+// NormalizeTags lowercases and trims tag names, dropping blanks and
+// duplicates while keeping the original order.
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
@@ -1,24 +1,47 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
 	"github.com/ariefsibuea/articles-feed/internal/api/usecase"
+	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
 type articleHandler struct {
 	articleUseCase usecase.ArticleUseCase
+	adminToken     string
+	publicBaseURL  string
 }
 
-func InitArticleHandler(e *echo.Echo, articleUseCase usecase.ArticleUseCase) {
+// InitArticleHandler registers the article routes. publicBaseURL is where the
+// API is reachable from clients, used for the links of negotiated feeds.
+func InitArticleHandler(e *echo.Echo, articleUseCase usecase.ArticleUseCase, adminToken, publicBaseURL string) {
 	handler := &articleHandler{
 		articleUseCase: articleUseCase,
+		adminToken:     adminToken,
+		publicBaseURL:  strings.TrimSuffix(publicBaseURL, "/"),
 	}
 
 	e.POST("/articles", handler.create)
 	e.GET("/articles", handler.get)
+	e.GET("/articles/:id", handler.getByUUID)
+	e.PUT("/articles/:id", handler.update)
+	e.PATCH("/articles/:id", handler.patch)
+	e.DELETE("/articles/:id", handler.delete)
+	e.POST("/articles/:id/restore", handler.restore)
+	e.PUT("/articles/:id/status", handler.transition)
+	e.GET("/articles/:id/revisions", handler.getRevisions)
+	e.GET("/articles/:id/revisions/diff", handler.diffRevisions)
+	e.GET("/articles/:id/revisions/:rev", handler.getRevision)
+	e.POST("/articles/:id/revisions/:rev/revert", handler.revertToRevision)
 }
 
 func (h *articleHandler) create(c echo.Context) error {
@@ -50,14 +73,230 @@ func (h *articleHandler) get(c echo.Context) error {
 		return err
 	}
 
-	res, err := h.articleUseCase.GetArticles(ctx, req.ToFilterDomain())
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	if req.IncludeDeleted && !isAdmin(c, h.adminToken) {
+		return _errors.UnauthorizedErrorf("'includeDeleted' requires an admin token")
+	}
+
+	filter, err := req.ToFilterDomain()
 	if err != nil {
 		return err
 	}
 
-	return Success(c, http.StatusOK, GetArticlesResponseFromDomain(res), &Meta{
-		Page:       res.Page,
-		PageSize:   res.PageSize,
-		TotalItems: res.TotalItems,
+	renderer, err := negotiateArticleListRenderer(c.Request().Header.Get(echo.HeaderAccept))
+	if err != nil {
+		return err
+	}
+
+	res, err := h.articleUseCase.GetArticles(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	setCacheHeader(c, res)
+	c.Set(feedBaseURLKey, h.publicBaseURL)
+	return renderConditional(c, "", res.LastModified, func() error {
+		return renderer.Render(c, res)
 	})
 }
+
+func (h *articleHandler) getByUUID(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	articleUUID, err := articleUUIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.articleUseCase.GetByUUID(ctx, articleUUID)
+	if err != nil {
+		return err
+	}
+
+	return renderArticle(c, res)
+}
+
+func (h *articleHandler) update(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	articleUUID, err := articleUUIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(UpdateArticleRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	expectedVersion, hasIfMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+	if !hasIfMatch {
+		expectedVersion = req.Version
+	}
+
+	res, err := h.articleUseCase.Update(ctx, req.ToDomain(articleUUID), expectedVersion)
+	if err != nil {
+		return preconditionError(err, hasIfMatch)
+	}
+
+	return renderArticle(c, res)
+}
+
+func (h *articleHandler) patch(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	articleUUID, err := articleUUIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	// echo's binder only understands application/json, so the merge patch
+	// document is decoded directly
+	req := new(PatchArticleRequest)
+	if err := json.NewDecoder(c.Request().Body).Decode(req); err != nil {
+		var errCustom _errors.CustomError
+		if errors.As(err, &errCustom) {
+			return err
+		}
+		return _errors.BadRequestErrorf("invalid merge patch document")
+	}
+
+	expectedVersion, hasIfMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+	if !hasIfMatch {
+		expectedVersion = req.Version
+	}
+
+	res, err := h.articleUseCase.Patch(ctx, articleUUID, req.ToDomain(), expectedVersion)
+	if err != nil {
+		return preconditionError(err, hasIfMatch)
+	}
+
+	return renderArticle(c, res)
+}
+
+func (h *articleHandler) delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	articleUUID, err := articleUUIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.articleUseCase.Delete(ctx, articleUUID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *articleHandler) restore(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	articleUUID, err := articleUUIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.articleUseCase.Restore(ctx, articleUUID)
+	if err != nil {
+		return err
+	}
+
+	return renderArticle(c, res)
+}
+
+func (h *articleHandler) transition(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	articleUUID, err := articleUUIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(TransitionArticleRequest)
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	res, err := h.articleUseCase.Transition(ctx, articleUUID, domain.ArticleStatus(req.Status), req.PublishTime())
+	if err != nil {
+		return err
+	}
+
+	return renderArticle(c, res)
+}
+
+func articleUUIDParam(c echo.Context) (string, error) {
+	articleUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return "", _errors.BadRequestErrorf("invalid article id '%s'", c.Param("id"))
+	}
+	return articleUUID.String(), nil
+}
+
+// renderArticle responds with article, tagged alike on reads and writes so
+// that the ETag of either can be sent back in If-None-Match. The version leads
+// the ETag, so it can also be sent back in If-Match.
+func renderArticle(c echo.Context, article domain.Article) error {
+	etagPrefix := strconv.Itoa(int(article.Version)) + "."
+	return renderConditional(c, etagPrefix, articleLastModified(article), func() error {
+		return Success(c, http.StatusOK, ArticleResponseFromDomain(article), nil)
+	})
+}
+
+// ifMatchVersion reads the article version out of an If-Match header. The
+// version alone decides whether the tag matches, as every change to an article
+// bumps it. The second return value reports whether the header was present at
+// all; "*" matches any version and yields zero.
+func ifMatchVersion(c echo.Context) (int32, bool, error) {
+	header := strings.TrimSpace(c.Request().Header.Get(HeaderIfMatch))
+	if header == "" {
+		return 0, false, nil
+	}
+	if header == "*" {
+		return 0, true, nil
+	}
+
+	// weak tags fail to unquote, so they never match
+	tag, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, true, _errors.PreconditionFailedErrorf("If-Match does not match the current article version")
+	}
+
+	// GET /articles/:id tags the version followed by a hash of the body, which
+	// is left out as the version already identifies the content
+	tag, _, _ = strings.Cut(tag, ".")
+	version, err := strconv.ParseInt(tag, 10, 32)
+	if err != nil || version <= 0 {
+		return 0, true, _errors.PreconditionFailedErrorf("If-Match does not match the current article version")
+	}
+
+	return int32(version), true, nil
+}
+
+// preconditionError reports a stale version as 412 when the client used
+// If-Match, and as 409 when it sent the version in the body.
+func preconditionError(err error, hasIfMatch bool) error {
+	if hasIfMatch && errors.Is(err, _errors.ErrArticleVersionConflict) {
+		return _errors.PreconditionFailedErrorf("If-Match does not match the current article version")
+	}
+	return err
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *articleHandler) getRevisions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	articleUUID, err := articleUUIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.articleUseCase.GetRevisions(ctx, articleUUID)
+	if err != nil {
+		return err
+	}
+
+	return Success(c, http.StatusOK, GetArticleRevisionsResponseFromDomain(res), nil)
+}
+
+func (h *articleHandler) getRevision(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	articleUUID, err := articleUUIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	revision, err := revisionParam(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.articleUseCase.GetRevision(ctx, articleUUID, revision)
+	if err != nil {
+		return err
+	}
+
+	return Success(c, http.StatusOK, ArticleRevisionResponseFromDomain(res), nil)
+}
+
+func (h *articleHandler) revertToRevision(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	articleUUID, err := articleUUIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	revision, err := revisionParam(c)
+	if err != nil {
+		return err
+	}
+
+	expectedVersion, hasIfMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.articleUseCase.RevertToRevision(ctx, articleUUID, revision, expectedVersion)
+	if err != nil {
+		return preconditionError(err, hasIfMatch)
+	}
+
+	return renderArticle(c, res)
+}
+
+func (h *articleHandler) diffRevisions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	articleUUID, err := articleUUIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	binder := new(echo.DefaultBinder)
+	req := new(DiffArticleRevisionsRequest)
+	if err := binder.BindQueryParams(c, req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	res, err := h.articleUseCase.DiffRevisions(ctx, articleUUID, req.From, req.To)
+	if err != nil {
+		return err
+	}
+
+	return Success(c, http.StatusOK, ArticleRevisionDiffResponseFromDomain(res), nil)
+}
+
+func revisionParam(c echo.Context) (int32, error) {
+	revision, err := strconv.ParseInt(c.Param("rev"), 10, 32)
+	if err != nil || revision <= 0 {
+		return 0, _errors.BadRequestErrorf("invalid revision '%s'", c.Param("rev"))
+	}
+	return int32(revision), nil
+}
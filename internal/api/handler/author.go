@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/usecase"
+	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type authorHandler struct {
+	authorUseCase usecase.AuthorUseCase
+	publicBaseURL string
+}
+
+func InitAuthorHandler(e *echo.Echo, authorUseCase usecase.AuthorUseCase, publicBaseURL string) {
+	handler := &authorHandler{
+		authorUseCase: authorUseCase,
+		publicBaseURL: strings.TrimSuffix(publicBaseURL, "/"),
+	}
+
+	e.GET("/authors", handler.get)
+	e.GET("/authors/:id", handler.getByUUID)
+	e.GET("/authors/:id/articles", handler.getArticles)
+	e.GET("/authors/:id/feed.rss", handler.getFeed)
+}
+
+func (h *authorHandler) get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	binder := new(echo.DefaultBinder)
+	req := new(GetAuthorsRequest)
+	if err := binder.BindQueryParams(c, req); err != nil {
+		return err
+	}
+
+	res, err := h.authorUseCase.GetAuthors(ctx, req.ToFilterDomain())
+	if err != nil {
+		return err
+	}
+
+	return Success(c, http.StatusOK, GetAuthorsResponseFromDomain(res), &Meta{
+		Page:       res.Page,
+		PageSize:   res.PageSize,
+		TotalItems: res.TotalItems,
+	})
+}
+
+func (h *authorHandler) getByUUID(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	authorUUID, err := authorUUIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.authorUseCase.GetByUUID(ctx, authorUUID)
+	if err != nil {
+		return err
+	}
+
+	return Success(c, http.StatusOK, AuthorResponseFromDomain(res), nil)
+}
+
+func (h *authorHandler) getArticles(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	authorUUID, err := authorUUIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	binder := new(echo.DefaultBinder)
+	req := new(GetArticlesRequest)
+	if err := binder.BindQueryParams(c, req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	filter, err := req.ToFilterDomain()
+	if err != nil {
+		return err
+	}
+
+	// deleted articles are only reviewed through GET /articles
+	filter.IncludeDeleted = false
+
+	renderer, err := negotiateArticleListRenderer(c.Request().Header.Get(echo.HeaderAccept))
+	if err != nil {
+		return err
+	}
+
+	_, res, err := h.authorUseCase.GetArticles(ctx, authorUUID, filter)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	c.Set(feedBaseURLKey, h.publicBaseURL)
+	return renderConditional(c, "", res.LastModified, func() error {
+		return renderer.Render(c, res)
+	})
+}
+
+func (h *authorHandler) getFeed(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	authorUUID, err := authorUUIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	filter, err := bindFeedFilter(c)
+	if err != nil {
+		return err
+	}
+
+	author, res, err := h.authorUseCase.GetArticles(ctx, authorUUID, filter)
+	if err != nil {
+		return err
+	}
+
+	c.Set(feedTitleKey, feedTitle+" - "+author.Name)
+	c.Set(feedBaseURLKey, h.publicBaseURL)
+	return renderConditional(c, "", res.LastModified, func() error {
+		return lookupArticleListRenderer(MIMEApplicationRSS).Render(c, res)
+	})
+}
+
+func authorUUIDParam(c echo.Context) (string, error) {
+	authorUUID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return "", _errors.BadRequestErrorf("invalid author id '%s'", c.Param("id"))
+	}
+	return authorUUID.String(), nil
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// bufferedResponse holds a rendered response back so that it can be tagged
+// before anything is sent. It shares the headers of the real response.
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponse) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponse) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedResponse) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+// renderConditional renders a response with a strong ETag over its body,
+// prefixed by etagPrefix, and a Last-Modified of lastModified unless it is
+// zero. A GET from a client whose copy is still current gets 304 Not Modified
+// instead.
+func renderConditional(c echo.Context, etagPrefix string, lastModified time.Time, render func() error) error {
+	res := c.Response()
+	writer := res.Writer
+	buffer := &bufferedResponse{header: writer.Header(), status: http.StatusOK}
+
+	res.Writer = buffer
+	err := render()
+	res.Writer = writer
+	if err != nil {
+		return err
+	}
+
+	if buffer.status != http.StatusOK {
+		writer.WriteHeader(buffer.status)
+		_, err = writer.Write(buffer.body.Bytes())
+		return err
+	}
+
+	sum := sha256.Sum256(buffer.body.Bytes())
+	etag := strconv.Quote(etagPrefix + hex.EncodeToString(sum[:16]))
+
+	header := res.Header()
+	header.Set(HeaderETag, etag)
+	if !lastModified.IsZero() {
+		header.Set(echo.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if c.Request().Method == http.MethodGet && notModified(c.Request(), etag, lastModified) {
+		header.Del(echo.HeaderContentType)
+		header.Del(echo.HeaderContentLength)
+		res.Status = http.StatusNotModified
+		writer.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	_, err = writer.Write(buffer.body.Bytes())
+	return err
+}
+
+// notModified evaluates If-None-Match, or If-Modified-Since when the former
+// is absent, as RFC 9110 orders them.
+func notModified(req *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := req.Header.Get(HeaderIfNoneMatch); ifNoneMatch != "" {
+		return etagListMatches(ifNoneMatch, etag)
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+
+	since, err := http.ParseTime(req.Header.Get(echo.HeaderIfModifiedSince))
+	if err != nil {
+		return false
+	}
+
+	// HTTP dates only carry seconds
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// etagListMatches compares the entity tags of an If-None-Match header with
+// etag. If-None-Match uses weak comparison, so W/ prefixes are ignored.
+func etagListMatches(list, etag string) bool {
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// articleLastModified is the newest creation, edit or past publication of the
+// articles, or zero if there are none. A scheduled article going live is not
+// a write, so its publication counts as a change.
+func articleLastModified(articles ...domain.Article) time.Time {
+	now := time.Now()
+
+	var lastModified time.Time
+	for _, a := range articles {
+		if a.CreatedAt.After(lastModified) {
+			lastModified = a.CreatedAt
+		}
+		if a.UpdatedAt.After(lastModified) {
+			lastModified = a.UpdatedAt
+		}
+		if a.PublishAt.After(lastModified) && !a.PublishAt.After(now) {
+			lastModified = a.PublishAt
+		}
+	}
+	return lastModified
+}
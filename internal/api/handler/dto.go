@@ -1,17 +1,28 @@
 package handler
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
+
+	"github.com/google/uuid"
 )
 
 type CreateArticleRequest struct {
-	Title      string `json:"title"`
-	AuthorName string `json:"authorName"`
-	Body       string `json:"body"`
+	Title      string     `json:"title"`
+	AuthorName string     `json:"authorName"`
+	Body       string     `json:"body"`
+	Status     string     `json:"status"`
+	PublishAt  *time.Time `json:"publishAt"`
+	Language   string     `json:"language"`
+	Tags       []string   `json:"tags"`
 }
 
 func (req *CreateArticleRequest) Validate() error {
@@ -21,23 +32,44 @@ func (req *CreateArticleRequest) Validate() error {
 	if strings.TrimSpace(req.AuthorName) == "" {
 		return fmt.Errorf("'authorName' is required")
 	}
+	if req.Status != "" && !domain.ArticleStatus(req.Status).IsValid() {
+		return _errors.BadRequestErrorf("invalid status '%s'", req.Status)
+	}
+	for _, tag := range req.Tags {
+		if len(strings.TrimSpace(tag)) > maxTagLength {
+			return _errors.BadRequestErrorf("tag '%s' is longer than %d characters", tag, maxTagLength)
+		}
+	}
 	return nil
 }
 
 func (req *CreateArticleRequest) ToDomain() domain.Article {
-	return domain.Article{
+	article := domain.Article{
 		AuthorName: req.AuthorName,
 		Title:      req.Title,
 		Body:       req.Body,
+		Status:     domain.ArticleStatus(req.Status),
+		Language:   domain.NormalizeLanguage(req.Language),
+		Tags:       domain.NormalizeTags(req.Tags),
 	}
+
+	if req.PublishAt != nil {
+		article.PublishAt = req.PublishAt.In(time.UTC)
+	}
+
+	return article
 }
 
 type CreateArticleResponse struct {
-	ID         string    `json:"id"`
-	Title      string    `json:"title"`
-	AuthorName string    `json:"authorName"`
-	Body       string    `json:"body"`
-	CreatedAt  time.Time `json:"createdAt"`
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	AuthorName string     `json:"authorName"`
+	Body       string     `json:"body"`
+	Status     string     `json:"status"`
+	PublishAt  *time.Time `json:"publishAt,omitempty"`
+	Language   string     `json:"language"`
+	Tags       []string   `json:"tags"`
+	CreatedAt  time.Time  `json:"createdAt"`
 }
 
 func CreateArticleResponseFromDomain(article domain.Article) CreateArticleResponse {
@@ -46,23 +78,162 @@ func CreateArticleResponseFromDomain(article domain.Article) CreateArticleRespon
 		Title:      article.Title,
 		AuthorName: article.AuthorName,
 		Body:       article.Body,
+		Status:     string(article.Status),
+		PublishAt:  timePtr(article.PublishAt),
+		Language:   article.Language,
+		Tags:       tagsOrEmpty(article.Tags),
 		CreatedAt:  article.CreatedAt,
 	}
 }
 
+type TransitionArticleRequest struct {
+	Status    string     `json:"status"`
+	PublishAt *time.Time `json:"publishAt"`
+}
+
+func (req *TransitionArticleRequest) Validate() error {
+	if !domain.ArticleStatus(req.Status).IsValid() {
+		return _errors.BadRequestErrorf("invalid status '%s'", req.Status)
+	}
+	return nil
+}
+
+func (req *TransitionArticleRequest) PublishTime() time.Time {
+	if req.PublishAt == nil {
+		return time.Time{}
+	}
+	return req.PublishAt.In(time.UTC)
+}
+
 type GetArticlesRequest struct {
-	Page       int32  `query:"page"`
-	PageSize   int32  `query:"pageSize"`
-	Query      string `query:"query"`
-	AuthorName string `query:"authorName"`
+	Page           int32    `query:"page"`
+	PageSize       int32    `query:"pageSize"`
+	Query          string   `query:"query"`
+	SearchMode     string   `query:"searchMode"`
+	Lang           string   `query:"lang"`
+	Fuzzy          bool     `query:"fuzzy"`
+	FuzzyThreshold float64  `query:"fuzzyThreshold"`
+	AuthorName     string   `query:"authorName"`
+	Tags           []string `query:"tag"`
+	TagMode        string   `query:"tagMode"`
+	Cursor         string   `query:"cursor"`
+	SkipCount      bool     `query:"skipCount"`
+	Sort           string   `query:"sort"`
+	CreatedFrom    string   `query:"createdFrom"`
+	CreatedTo      string   `query:"createdTo"`
+	Highlight      bool     `query:"highlight"`
+	HighlightStart string   `query:"highlightStart"`
+	HighlightStop  string   `query:"highlightStop"`
+	MaxFragments   int32    `query:"maxFragments"`
+	IncludeDeleted bool     `query:"includeDeleted"`
+	Facets         string   `query:"facets"`
 }
 
-func (req *GetArticlesRequest) ToFilterDomain() domain.ArticleFilter {
+func (req *GetArticlesRequest) Validate() error {
+	switch domain.TagMatchMode(req.TagMode) {
+	case "", domain.TagMatchAny, domain.TagMatchAll:
+	default:
+		return _errors.BadRequestErrorf("'tagMode' must be either 'any' or 'all'")
+	}
+
+	searchMode := domain.SearchMode(req.SearchMode)
+	if searchMode != "" && !searchMode.IsValid() {
+		return _errors.BadRequestErrorf("'searchMode' must be one of plain, websearch or prefix")
+	}
+	if searchMode == domain.SearchModePrefix && strings.TrimSpace(req.Query) != "" && !hasSearchWord(req.Query) {
+		return _errors.BadRequestErrorf("'query' must contain at least one letter or digit in prefix mode")
+	}
+
+	if req.FuzzyThreshold < 0 || req.FuzzyThreshold > 1 {
+		return _errors.BadRequestErrorf("'fuzzyThreshold' must be between 0 and 1")
+	}
+
+	sort := domain.ArticleSort(req.Sort)
+	if sort != "" && !sort.IsValid() {
+		return _errors.BadRequestErrorf("'sort' must be one of createdAt, -createdAt, title, -title, author, -author, relevance or similarity")
+	}
+	if sort == domain.ArticleSortRelevance && (strings.TrimSpace(req.Query) == "" || req.Fuzzy) {
+		return _errors.BadRequestErrorf("'sort=relevance' requires 'query' without 'fuzzy'")
+	}
+	if sort == domain.ArticleSortSimilarity && !req.Fuzzy {
+		return _errors.BadRequestErrorf("'sort=similarity' requires 'fuzzy=true'")
+	}
+	// fuzzy searches are ordered by similarity unless told otherwise
+	if sort == "" && req.Fuzzy {
+		sort = domain.ArticleSortSimilarity
+	}
+	if req.Cursor != "" && sort != "" && sort != domain.DefaultArticleSort {
+		return _errors.BadRequestErrorf("'cursor' can only be used with the default sort")
+	}
+
+	if err := validateHighlightMarker("highlightStart", req.HighlightStart); err != nil {
+		return err
+	}
+	if err := validateHighlightMarker("highlightStop", req.HighlightStop); err != nil {
+		return err
+	}
+	if req.MaxFragments < 0 || req.MaxFragments > maxHighlightFragments {
+		return _errors.BadRequestErrorf("'maxFragments' must be between 0 and %d", maxHighlightFragments)
+	}
+
+	for _, facet := range req.facets() {
+		if !facet.IsValid() {
+			return _errors.BadRequestErrorf("'facets' must be a comma-separated list of author, month or tag")
+		}
+	}
+	return nil
+}
+
+// facets splits the comma-separated facets parameter, dropping duplicates.
+func (req *GetArticlesRequest) facets() []domain.ArticleFacet {
+	facets := make([]domain.ArticleFacet, 0)
+	for _, name := range strings.Split(req.Facets, ",") {
+		facet := domain.ArticleFacet(strings.TrimSpace(name))
+		if facet != "" && !slices.Contains(facets, facet) {
+			facets = append(facets, facet)
+		}
+	}
+	return facets
+}
+
+func (req *GetArticlesRequest) ToFilterDomain() (domain.ArticleFilter, error) {
 	articleFilter := domain.ArticleFilter{
 		Page:       req.Page,
 		PageSize:   req.PageSize,
 		Query:      req.Query,
+		SearchMode: domain.SearchMode(req.SearchMode),
+		Language:   domain.NormalizeLanguage(req.Lang),
+		Fuzzy:      req.Fuzzy,
 		AuthorName: req.AuthorName,
+		Tags:       domain.NormalizeTags(req.Tags),
+		TagMode:    domain.TagMatchMode(req.TagMode),
+		SkipCount:  req.SkipCount,
+		Sort:       domain.ArticleSort(req.Sort),
+
+		FuzzyThreshold: req.FuzzyThreshold,
+		IncludeDeleted: req.IncludeDeleted,
+	}
+
+	if facets := req.facets(); len(facets) > 0 {
+		articleFilter.Facets = facets
+	}
+
+	if req.Highlight {
+		articleFilter.Highlight = &domain.HighlightOptions{
+			StartSel:     req.HighlightStart,
+			StopSel:      req.HighlightStop,
+			MaxFragments: req.MaxFragments,
+		}
+
+		if articleFilter.Highlight.StartSel == "" {
+			articleFilter.Highlight.StartSel = "<mark>"
+		}
+		if articleFilter.Highlight.StopSel == "" {
+			articleFilter.Highlight.StopSel = "</mark>"
+		}
+		if articleFilter.Highlight.MaxFragments == 0 {
+			articleFilter.Highlight.MaxFragments = 2
+		}
 	}
 
 	if articleFilter.Page <= 0 {
@@ -71,16 +242,261 @@ func (req *GetArticlesRequest) ToFilterDomain() domain.ArticleFilter {
 	if articleFilter.PageSize <= 0 {
 		articleFilter.PageSize = 20
 	}
+	if articleFilter.TagMode == "" {
+		articleFilter.TagMode = domain.TagMatchAny
+	}
+	if articleFilter.Sort == "" {
+		articleFilter.Sort = domain.DefaultArticleSort
+		if articleFilter.Fuzzy {
+			articleFilter.Sort = domain.ArticleSortSimilarity
+		}
+	}
+	if articleFilter.Fuzzy && articleFilter.FuzzyThreshold == 0 {
+		articleFilter.FuzzyThreshold = defaultFuzzyThreshold
+	}
+	if articleFilter.SearchMode == "" {
+		articleFilter.SearchMode = domain.SearchModePlain
+	}
 
-	return articleFilter
+	if req.CreatedFrom != "" {
+		createdFrom, err := time.Parse(time.RFC3339, req.CreatedFrom)
+		if err != nil {
+			return domain.ArticleFilter{}, _errors.BadRequestErrorf("'createdFrom' must be an RFC3339 timestamp")
+		}
+		articleFilter.CreatedFrom = createdFrom.In(time.UTC)
+	}
+	if req.CreatedTo != "" {
+		createdTo, err := time.Parse(time.RFC3339, req.CreatedTo)
+		if err != nil {
+			return domain.ArticleFilter{}, _errors.BadRequestErrorf("'createdTo' must be an RFC3339 timestamp")
+		}
+		articleFilter.CreatedTo = createdTo.In(time.UTC)
+	}
+	if !articleFilter.CreatedFrom.IsZero() && !articleFilter.CreatedTo.IsZero() && articleFilter.CreatedFrom.After(articleFilter.CreatedTo) {
+		return domain.ArticleFilter{}, _errors.BadRequestErrorf("'createdFrom' must not be after 'createdTo'")
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodeArticleCursor(req.Cursor)
+		if err != nil {
+			return domain.ArticleFilter{}, err
+		}
+		articleFilter.Cursor = cursor
+	}
+
+	return articleFilter, nil
+}
+
+func hasSearchWord(q string) bool {
+	return strings.IndexFunc(q, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}) >= 0
+}
+
+// validateHighlightMarker rejects double quotes, as markers are passed to
+// ts_headline inside a quoted option value.
+func validateHighlightMarker(key, marker string) error {
+	if len(marker) > maxHighlightMarkerLength || strings.Contains(marker, `"`) {
+		return _errors.BadRequestErrorf("'%s' must be at most %d characters and must not contain '\"'", key, maxHighlightMarkerLength)
+	}
+	return nil
+}
+
+// articleCursorToken is the JSON form of an opaque listing cursor.
+type articleCursorToken struct {
+	CreatedAt time.Time `json:"t"`
+	UUID      string    `json:"id"`
+	Backward  bool      `json:"b,omitempty"`
+}
+
+func encodeArticleCursor(cursor *domain.ArticleCursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	token, _ := json.Marshal(articleCursorToken{
+		CreatedAt: cursor.CreatedAt,
+		UUID:      cursor.UUID,
+		Backward:  cursor.Backward,
+	})
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+func decodeArticleCursor(value string) (*domain.ArticleCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, _errors.BadRequestErrorf("invalid cursor")
+	}
+
+	token := articleCursorToken{}
+	if err := json.Unmarshal(raw, &token); err != nil || token.CreatedAt.IsZero() {
+		return nil, _errors.BadRequestErrorf("invalid cursor")
+	}
+
+	if _, err := uuid.Parse(token.UUID); err != nil {
+		return nil, _errors.BadRequestErrorf("invalid cursor")
+	}
+
+	return &domain.ArticleCursor{
+		CreatedAt: token.CreatedAt,
+		UUID:      token.UUID,
+		Backward:  token.Backward,
+	}, nil
+}
+
+const (
+	maxTagLength             = 64
+	maxHighlightMarkerLength = 32
+	maxHighlightFragments    = 10
+	defaultFuzzyThreshold    = 0.3
+	maxSuggestionPrefix      = 100
+	defaultSuggestionLimit   = 5
+	maxSuggestionLimit       = 20
+)
+
+type UpdateArticleRequest struct {
+	Title      string `json:"title"`
+	AuthorName string `json:"authorName"`
+	Body       string `json:"body"`
+	Version    int32  `json:"version"`
+}
+
+func (req *UpdateArticleRequest) Validate() error {
+	if strings.TrimSpace(req.Title) == "" {
+		return _errors.BadRequestErrorf("'title' is required")
+	}
+	if strings.TrimSpace(req.AuthorName) == "" {
+		return _errors.BadRequestErrorf("'authorName' is required")
+	}
+	if req.Version < 0 {
+		return _errors.BadRequestErrorf("'version' must not be negative")
+	}
+	return nil
+}
+
+func (req *UpdateArticleRequest) ToDomain(articleUUID string) domain.Article {
+	return domain.Article{
+		UUID:       articleUUID,
+		AuthorName: req.AuthorName,
+		Title:      req.Title,
+		Body:       req.Body,
+	}
+}
+
+// PatchArticleRequest is a JSON merge patch (RFC 7396) of an article. Keys
+// absent from the document are left untouched.
+type PatchArticleRequest struct {
+	Title      *string
+	AuthorName *string
+	Body       *string
+	Version    int32
+}
+
+func (req *PatchArticleRequest) UnmarshalJSON(data []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return _errors.BadRequestErrorf("merge patch must be a JSON object")
+	}
+
+	for key, raw := range fields {
+		switch key {
+		case "title", "authorName", "body":
+			var value *string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return _errors.BadRequestErrorf("'%s' must be a string or null", key)
+			}
+
+			switch key {
+			case "title":
+				if value == nil {
+					return _errors.BadRequestErrorf("'title' cannot be removed")
+				}
+				req.Title = value
+			case "authorName":
+				if value == nil {
+					return _errors.BadRequestErrorf("'authorName' cannot be removed")
+				}
+				req.AuthorName = value
+			case "body":
+				// null removes the body
+				if value == nil {
+					value = new(string)
+				}
+				req.Body = value
+			}
+		case "version":
+			if err := json.Unmarshal(raw, &req.Version); err != nil || req.Version < 0 {
+				return _errors.BadRequestErrorf("'version' must be a non-negative integer")
+			}
+		default:
+			return _errors.BadRequestErrorf("'%s' cannot be patched", key)
+		}
+	}
+
+	return nil
+}
+
+func (req *PatchArticleRequest) ToDomain() domain.ArticlePatch {
+	return domain.ArticlePatch{
+		Title:      req.Title,
+		AuthorName: req.AuthorName,
+		Body:       req.Body,
+	}
 }
 
 type ArticleResponse struct {
-	ID         string    `json:"id"`
-	Title      string    `json:"title"`
-	AuthorName string    `json:"authorName"`
-	Body       string    `json:"body"`
-	CreatedAt  time.Time `json:"createdAt"`
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	AuthorName string     `json:"authorName"`
+	Body       string     `json:"body"`
+	Status     string     `json:"status"`
+	PublishAt  *time.Time `json:"publishAt,omitempty"`
+	Language   string     `json:"language"`
+	Tags       []string   `json:"tags"`
+	Version    int32      `json:"version"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	DeletedAt  *time.Time `json:"deletedAt,omitempty"`
+
+	HighlightedTitle string `json:"highlightedTitle,omitempty"`
+	Snippet          string `json:"snippet,omitempty"`
+}
+
+func ArticleResponseFromDomain(article domain.Article) ArticleResponse {
+	return ArticleResponse{
+		ID:         article.UUID,
+		Title:      article.Title,
+		AuthorName: article.AuthorName,
+		Body:       article.Body,
+		Status:     string(article.Status),
+		PublishAt:  timePtr(article.PublishAt),
+		Language:   article.Language,
+		Tags:       tagsOrEmpty(article.Tags),
+		Version:    article.Version,
+		CreatedAt:  article.CreatedAt,
+		UpdatedAt:  article.UpdatedAt,
+		DeletedAt:  timePtr(article.DeletedAt),
+
+		HighlightedTitle: article.HighlightedTitle,
+		Snippet:          article.Snippet,
+	}
+}
+
+// tagsOrEmpty keeps untagged articles rendering "tags": [] instead of null.
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+// timePtr maps the zero time to nil so optional timestamps are omitted from
+// the response.
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
 }
 
 type GetArticlesResponse struct {
@@ -91,17 +507,258 @@ func GetArticlesResponseFromDomain(articleList domain.ArticleList) GetArticlesRe
 	articlesResponse := make([]ArticleResponse, 0, len(articleList.Articles))
 
 	for _, a := range articleList.Articles {
-		articleResponse := ArticleResponse{
-			ID:         a.UUID,
-			AuthorName: a.AuthorName,
-			Title:      a.Title,
-			Body:       a.Body,
-			CreatedAt:  a.CreatedAt,
-		}
-		articlesResponse = append(articlesResponse, articleResponse)
+		articlesResponse = append(articlesResponse, ArticleResponseFromDomain(a))
 	}
 
 	return GetArticlesResponse{
 		Articles: articlesResponse,
 	}
 }
+
+type FacetBucketResponse struct {
+	Value string `json:"value"`
+	Count int32  `json:"count"`
+}
+
+func FacetsResponseFromDomain(facets map[domain.ArticleFacet][]domain.FacetBucket) map[string][]FacetBucketResponse {
+	if len(facets) == 0 {
+		return nil
+	}
+
+	facetsResponse := make(map[string][]FacetBucketResponse, len(facets))
+	for facet, buckets := range facets {
+		bucketsResponse := make([]FacetBucketResponse, 0, len(buckets))
+		for _, b := range buckets {
+			bucketsResponse = append(bucketsResponse, FacetBucketResponse{
+				Value: b.Value,
+				Count: b.Count,
+			})
+		}
+		facetsResponse[string(facet)] = bucketsResponse
+	}
+
+	return facetsResponse
+}
+
+type ArticleRevisionResponse struct {
+	ArticleID  string    `json:"articleId"`
+	Revision   int32     `json:"revision"`
+	Title      string    `json:"title"`
+	AuthorName string    `json:"authorName"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func ArticleRevisionResponseFromDomain(revision domain.ArticleRevision) ArticleRevisionResponse {
+	return ArticleRevisionResponse{
+		ArticleID:  revision.ArticleUUID,
+		Revision:   revision.Revision,
+		Title:      revision.Title,
+		AuthorName: revision.AuthorName,
+		Body:       revision.Body,
+		CreatedAt:  revision.CreatedAt,
+	}
+}
+
+type GetArticleRevisionsResponse struct {
+	Revisions []ArticleRevisionResponse `json:"revisions"`
+}
+
+func GetArticleRevisionsResponseFromDomain(revisions []domain.ArticleRevision) GetArticleRevisionsResponse {
+	revisionsResponse := make([]ArticleRevisionResponse, 0, len(revisions))
+
+	for _, r := range revisions {
+		revisionsResponse = append(revisionsResponse, ArticleRevisionResponseFromDomain(r))
+	}
+
+	return GetArticleRevisionsResponse{
+		Revisions: revisionsResponse,
+	}
+}
+
+type DiffArticleRevisionsRequest struct {
+	From int32 `query:"from"`
+	To   int32 `query:"to"`
+}
+
+func (req *DiffArticleRevisionsRequest) Validate() error {
+	if req.From <= 0 {
+		return _errors.BadRequestErrorf("'from' must be a positive revision number")
+	}
+	if req.To <= 0 {
+		return _errors.BadRequestErrorf("'to' must be a positive revision number")
+	}
+	return nil
+}
+
+type ArticleRevisionDiffResponse struct {
+	ArticleID    string `json:"articleId"`
+	FromRevision int32  `json:"fromRevision"`
+	ToRevision   int32  `json:"toRevision"`
+	Diff         string `json:"diff"`
+}
+
+func ArticleRevisionDiffResponseFromDomain(diff domain.ArticleRevisionDiff) ArticleRevisionDiffResponse {
+	return ArticleRevisionDiffResponse{
+		ArticleID:    diff.ArticleUUID,
+		FromRevision: diff.FromRevision,
+		ToRevision:   diff.ToRevision,
+		Diff:         diff.Diff,
+	}
+}
+
+type GetAuthorsRequest struct {
+	Page     int32 `query:"page"`
+	PageSize int32 `query:"pageSize"`
+}
+
+func (req *GetAuthorsRequest) ToFilterDomain() domain.AuthorFilter {
+	authorFilter := domain.AuthorFilter{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+
+	if authorFilter.Page <= 0 {
+		authorFilter.Page = 1
+	}
+	if authorFilter.PageSize <= 0 {
+		authorFilter.PageSize = 20
+	}
+
+	return authorFilter
+}
+
+type AuthorResponse struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	ArticleCount    int32      `json:"articleCount"`
+	LatestArticleAt *time.Time `json:"latestArticleAt,omitempty"`
+}
+
+func AuthorResponseFromDomain(author domain.Author) AuthorResponse {
+	return AuthorResponse{
+		ID:              author.UUID,
+		Name:            author.Name,
+		ArticleCount:    author.ArticleCount,
+		LatestArticleAt: timePtr(author.LatestArticleAt),
+	}
+}
+
+type GetAuthorsResponse struct {
+	Authors []AuthorResponse `json:"authors"`
+}
+
+func GetAuthorsResponseFromDomain(authorList domain.AuthorList) GetAuthorsResponse {
+	authorsResponse := make([]AuthorResponse, 0, len(authorList.Authors))
+
+	for _, a := range authorList.Authors {
+		authorsResponse = append(authorsResponse, AuthorResponseFromDomain(a))
+	}
+
+	return GetAuthorsResponse{
+		Authors: authorsResponse,
+	}
+}
+
+type GetTagsRequest struct {
+	Limit int32 `query:"limit"`
+}
+
+func (req *GetTagsRequest) Validate() error {
+	if req.Limit < 0 {
+		return _errors.BadRequestErrorf("'limit' must not be negative")
+	}
+	return nil
+}
+
+type TagResponse struct {
+	Name         string `json:"name"`
+	ArticleCount int32  `json:"articleCount"`
+}
+
+type GetTagsResponse struct {
+	Tags []TagResponse `json:"tags"`
+}
+
+func GetTagsResponseFromDomain(tags []domain.Tag) GetTagsResponse {
+	tagsResponse := make([]TagResponse, 0, len(tags))
+
+	for _, t := range tags {
+		tagResponse := TagResponse{
+			Name:         t.Name,
+			ArticleCount: t.ArticleCount,
+		}
+		tagsResponse = append(tagsResponse, tagResponse)
+	}
+
+	return GetTagsResponse{
+		Tags: tagsResponse,
+	}
+}
+
+type GetSuggestionsRequest struct {
+	Q     string `query:"q"`
+	Type  string `query:"type"`
+	Limit int32  `query:"limit"`
+}
+
+func (req *GetSuggestionsRequest) Validate() error {
+	if req.Prefix() == "" {
+		return _errors.BadRequestErrorf("'q' is required")
+	}
+	if len(req.Prefix()) > maxSuggestionPrefix {
+		return _errors.BadRequestErrorf("'q' must be at most %d characters", maxSuggestionPrefix)
+	}
+	switch domain.SuggestionType(req.Type) {
+	case "", domain.SuggestionTypeTitle, domain.SuggestionTypeAuthor:
+	default:
+		return _errors.BadRequestErrorf("'type' must be either 'title' or 'author'")
+	}
+	if req.Limit < 0 || req.Limit > maxSuggestionLimit {
+		return _errors.BadRequestErrorf("'limit' must be between 1 and %d", maxSuggestionLimit)
+	}
+	return nil
+}
+
+func (req *GetSuggestionsRequest) Prefix() string {
+	return strings.TrimSpace(req.Q)
+}
+
+func (req *GetSuggestionsRequest) SuggestionType() domain.SuggestionType {
+	if req.Type == "" {
+		return domain.SuggestionTypeTitle
+	}
+	return domain.SuggestionType(req.Type)
+}
+
+func (req *GetSuggestionsRequest) LimitOrDefault() int32 {
+	if req.Limit == 0 {
+		return defaultSuggestionLimit
+	}
+	return req.Limit
+}
+
+type SuggestionResponse struct {
+	ID    string `json:"id"`
+	Value string `json:"value"`
+}
+
+type GetSuggestionsResponse struct {
+	Suggestions []SuggestionResponse `json:"suggestions"`
+}
+
+func GetSuggestionsResponseFromDomain(suggestions []domain.Suggestion) GetSuggestionsResponse {
+	suggestionsResponse := make([]SuggestionResponse, 0, len(suggestions))
+
+	for _, s := range suggestions {
+		suggestionResponse := SuggestionResponse{
+			ID:    s.ID,
+			Value: s.Value,
+		}
+		suggestionsResponse = append(suggestionsResponse, suggestionResponse)
+	}
+
+	return GetSuggestionsResponse{
+		Suggestions: suggestionsResponse,
+	}
+}
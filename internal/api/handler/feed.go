@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+	"github.com/ariefsibuea/articles-feed/internal/api/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type feedHandler struct {
+	articleUseCase usecase.ArticleUseCase
+	publicBaseURL  string
+}
+
+func InitFeedHandler(e *echo.Echo, articleUseCase usecase.ArticleUseCase, publicBaseURL string) {
+	handler := &feedHandler{
+		articleUseCase: articleUseCase,
+		publicBaseURL:  strings.TrimSuffix(publicBaseURL, "/"),
+	}
+
+	e.GET("/feed.rss", handler.renderAs(MIMEApplicationRSS))
+	e.GET("/feed.atom", handler.renderAs(MIMEApplicationAtom))
+	e.GET("/feed.json", handler.renderAs(MIMEApplicationFeedJSON))
+}
+
+func (h *feedHandler) renderAs(mediaType string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res, err := h.getFeed(c)
+		if err != nil {
+			return err
+		}
+
+		setCacheHeader(c, res)
+		c.Set(feedBaseURLKey, h.publicBaseURL)
+		return renderConditional(c, "", res.LastModified, func() error {
+			return lookupArticleListRenderer(mediaType).Render(c, res)
+		})
+	}
+}
+
+// getFeed fetches the latest articles matching the same filters as
+// GET /articles.
+func (h *feedHandler) getFeed(c echo.Context) (domain.ArticleList, error) {
+	filter, err := bindFeedFilter(c)
+	if err != nil {
+		return domain.ArticleList{}, err
+	}
+
+	return h.articleUseCase.GetArticles(c.Request().Context(), filter)
+}
+
+// bindFeedFilter reads the GET /articles filters of a feed request. Feeds
+// only list what is publicly visible and never need a total.
+func bindFeedFilter(c echo.Context) (domain.ArticleFilter, error) {
+	binder := new(echo.DefaultBinder)
+	req := new(GetArticlesRequest)
+	if err := binder.BindQueryParams(c, req); err != nil {
+		return domain.ArticleFilter{}, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return domain.ArticleFilter{}, err
+	}
+
+	filter, err := req.ToFilterDomain()
+	if err != nil {
+		return domain.ArticleFilter{}, err
+	}
+
+	filter.IncludeDeleted = false
+	filter.SkipCount = true
+	filter.Facets = nil
+	filter.Highlight = nil
+
+	return filter, nil
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url,omitempty"`
+	NextURL     string         `json:"next_url,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url,omitempty"`
+	Title         string           `json:"title,omitempty"`
+	ContentText   string           `json:"content_text"`
+	DatePublished string           `json:"date_published,omitempty"`
+	DateModified  string           `json:"date_modified,omitempty"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	Language      string           `json:"language,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// newJSONFeed renders feed as JSON Feed 1.1, linking the next page through
+// the listing cursor.
+func newJSONFeed(feed articleFeed) jsonFeed {
+	items := make([]jsonFeedItem, 0, len(feed.Articles))
+	for _, a := range feed.Articles {
+		item := jsonFeedItem{
+			ID:            a.UUID,
+			URL:           feed.articleLink(a),
+			Title:         a.Title,
+			ContentText:   a.Body,
+			DatePublished: a.CreatedAt.UTC().Format(time.RFC3339),
+			DateModified:  a.UpdatedAt.UTC().Format(time.RFC3339),
+			Tags:          a.Tags,
+		}
+		if a.Language != domain.LanguageSimple {
+			item.Language = a.Language
+		}
+		if a.AuthorName != "" {
+			item.Authors = []jsonFeedAuthor{{Name: a.AuthorName}}
+		}
+
+		items = append(items, item)
+	}
+
+	return jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       feed.Title,
+		Description: feed.Description,
+		HomePageURL: feed.Link,
+		FeedURL:     feed.SelfLink,
+		NextURL:     feed.NextLink,
+		Items:       items,
+	}
+}
+
+func renderJSONFeed(c echo.Context, articleList domain.ArticleList) error {
+	body, err := json.Marshal(newJSONFeed(newArticleFeed(c, articleList)))
+	if err != nil {
+		return err
+	}
+
+	return c.Blob(http.StatusOK, MIMEApplicationFeedJSON+"; charset=UTF-8", body)
+}
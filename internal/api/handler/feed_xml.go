@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	MIMEApplicationRSS      = "application/rss+xml"
+	MIMEApplicationAtom     = "application/atom+xml"
+	MIMEApplicationFeedJSON = "application/feed+json"
+)
+
+const (
+	feedTitle       = "Articles Feed"
+	feedDescription = "The latest articles"
+)
+
+// feedTitleKey is the context key handlers can set to name a feed after what
+// it lists.
+const feedTitleKey = "feedTitle"
+
+// feedBaseURLKey is the context key holding the public URL of the API that
+// feed links are built from. The Host header of a request is not trusted for
+// it, as it is wrong behind a proxy and set by the client.
+const feedBaseURLKey = "feedBaseURL"
+
+// articleFeed is what every feed format is rendered from.
+type articleFeed struct {
+	ID          string
+	Title       string
+	Description string
+	Link        string
+	SelfLink    string
+	NextLink    string
+	Updated     time.Time
+	Articles    []domain.Article
+	BaseURL     string
+}
+
+func newArticleFeed(c echo.Context, articleList domain.ArticleList) articleFeed {
+	baseURL, _ := c.Get(feedBaseURLKey).(string)
+
+	// feeds without articles report the epoch, so they do not change between
+	// polls
+	updated := time.Unix(0, 0).UTC()
+	for _, a := range articleList.Articles {
+		if a.UpdatedAt.After(updated) {
+			updated = a.UpdatedAt
+		}
+	}
+
+	feed := articleFeed{
+		ID:          baseURL + c.Request().URL.Path,
+		Title:       feedTitle,
+		Description: feedDescription,
+		Link:        baseURL + "/articles",
+		SelfLink:    baseURL + c.Request().URL.RequestURI(),
+		Updated:     updated.UTC(),
+		Articles:    articleList.Articles,
+		BaseURL:     baseURL,
+	}
+
+	if title, ok := c.Get(feedTitleKey).(string); ok {
+		feed.Title = title
+	}
+
+	if articleList.NextCursor != nil {
+		query := c.Request().URL.Query()
+		query.Del("page")
+		query.Set("cursor", encodeArticleCursor(articleList.NextCursor))
+		feed.NextLink = baseURL + c.Request().URL.Path + "?" + query.Encode()
+	}
+
+	return feed
+}
+
+func (f articleFeed) articleLink(article domain.Article) string {
+	return f.BaseURL + "/articles/" + article.UUID
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	DCNS    string     `xml:"xmlns:dc,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	Creator     string  `xml:"dc:creator,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// newRSSFeed renders feed as RSS 2.0. RSS only allows an email address in
+// <author>, so author names go in <dc:creator>.
+func newRSSFeed(feed articleFeed) rssFeed {
+	items := make([]rssItem, 0, len(feed.Articles))
+	for _, a := range feed.Articles {
+		items = append(items, rssItem{
+			Title:       a.Title,
+			Link:        feed.articleLink(a),
+			Description: a.Body,
+			Creator:     a.AuthorName,
+			GUID:        rssGUID{Value: a.UUID},
+			PubDate:     a.CreatedAt.UTC().Format(time.RFC1123Z),
+		})
+	}
+
+	return rssFeed{
+		Version: "2.0",
+		DCNS:    "http://purl.org/dc/elements/1.1/",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         feed.Title,
+			Link:          feed.Link,
+			Description:   feed.Description,
+			AtomLink:      atomLink{Href: feed.SelfLink, Rel: "self", Type: MIMEApplicationRSS},
+			LastBuildDate: feed.Updated.Format(time.RFC1123Z),
+			Items:         items,
+		},
+	}
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Link      atomLink    `xml:"link"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Author    atomAuthor  `xml:"author"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// newAtomFeed renders feed as Atom 1.0, identifying entries by their article
+// UUID. The feed itself is identified by its URL without the query string, so
+// its id stays the same across pages and filters.
+func newAtomFeed(feed articleFeed) atomFeed {
+	entries := make([]atomEntry, 0, len(feed.Articles))
+	for _, a := range feed.Articles {
+		entries = append(entries, atomEntry{
+			ID:        "urn:uuid:" + a.UUID,
+			Title:     a.Title,
+			Link:      atomLink{Href: feed.articleLink(a), Rel: "alternate"},
+			Published: a.CreatedAt.UTC().Format(time.RFC3339),
+			Updated:   a.UpdatedAt.UTC().Format(time.RFC3339),
+			Author:    atomAuthor{Name: a.AuthorName},
+			Content:   atomContent{Type: "text", Value: a.Body},
+		})
+	}
+
+	return atomFeed{
+		ID:      feed.ID,
+		Title:   feed.Title,
+		Updated: feed.Updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: feed.SelfLink, Rel: "self", Type: MIMEApplicationAtom},
+			{Href: feed.Link, Rel: "alternate"},
+		},
+		Entries: entries,
+	}
+}
+
+func renderRSSFeed(c echo.Context, articleList domain.ArticleList) error {
+	return renderXML(c, MIMEApplicationRSS, newRSSFeed(newArticleFeed(c, articleList)))
+}
+
+func renderAtomFeed(c echo.Context, articleList domain.ArticleList) error {
+	return renderXML(c, MIMEApplicationAtom, newAtomFeed(newArticleFeed(c, articleList)))
+}
+
+func renderXML(c echo.Context, contentType string, v interface{}) error {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return c.Blob(http.StatusOK, contentType+"; charset=UTF-8", append([]byte(xml.Header), body...))
+}
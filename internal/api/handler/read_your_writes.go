@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/pkg/readpref"
+
+	"github.com/labstack/echo/v4"
+)
+
+// readPrimaryCookie holds, as Unix milliseconds, until when the reads of a
+// client that just wrote go to the primary.
+const readPrimaryCookie = "read_primary_until"
+
+// ReadYourWrites sends the reads of a client to the primary for window after
+// each of its successful writes, so it sees them even when read replicas lag
+// behind.
+func ReadYourWrites(window time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			now := time.Now()
+
+			if cookie, err := c.Cookie(readPrimaryCookie); err == nil {
+				until, err := strconv.ParseInt(cookie.Value, 10, 64)
+				if err == nil && now.Before(time.UnixMilli(until)) {
+					c.SetRequest(req.WithContext(readpref.WithPrimary(req.Context())))
+				}
+			}
+
+			if isWriteMethod(req.Method) {
+				c.Response().Before(func() {
+					if c.Response().Status >= http.StatusBadRequest {
+						return
+					}
+
+					until := now.Add(window)
+					c.SetCookie(&http.Cookie{
+						Name:     readPrimaryCookie,
+						Value:    strconv.FormatInt(until.UnixMilli(), 10),
+						Path:     "/",
+						Expires:  until,
+						HttpOnly: true,
+						SameSite: http.SameSiteLaxMode,
+					})
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ArticleListRenderer writes an article listing in one output format.
+type ArticleListRenderer interface {
+	Render(c echo.Context, articleList domain.ArticleList) error
+}
+
+type ArticleListRendererFunc func(c echo.Context, articleList domain.ArticleList) error
+
+func (f ArticleListRendererFunc) Render(c echo.Context, articleList domain.ArticleList) error {
+	return f(c, articleList)
+}
+
+type articleListRenderer struct {
+	mediaType string
+	renderer  ArticleListRenderer
+}
+
+// articleListRenderers holds the formats GET /articles can respond with, in
+// order of preference when the client accepts several equally. The first one
+// is the default.
+var articleListRenderers = []articleListRenderer{
+	{mediaType: echo.MIMEApplicationJSON, renderer: ArticleListRendererFunc(renderArticleListJSON)},
+	{mediaType: MIMEApplicationFeedJSON, renderer: ArticleListRendererFunc(renderJSONFeed)},
+	{mediaType: MIMEApplicationRSS, renderer: ArticleListRendererFunc(renderRSSFeed)},
+	{mediaType: MIMEApplicationAtom, renderer: ArticleListRendererFunc(renderAtomFeed)},
+}
+
+// RegisterArticleListRenderer adds an output format for GET /articles, or
+// replaces the renderer of a media type already registered. It is not safe
+// to call while the server is handling requests.
+func RegisterArticleListRenderer(mediaType string, renderer ArticleListRenderer) {
+	mediaType = strings.ToLower(mediaType)
+	for i, r := range articleListRenderers {
+		if r.mediaType == mediaType {
+			articleListRenderers[i].renderer = renderer
+			return
+		}
+	}
+
+	articleListRenderers = append(articleListRenderers, articleListRenderer{mediaType: mediaType, renderer: renderer})
+}
+
+func lookupArticleListRenderer(mediaType string) ArticleListRenderer {
+	for _, r := range articleListRenderers {
+		if r.mediaType == mediaType {
+			return r.renderer
+		}
+	}
+	return nil
+}
+
+// negotiateArticleListRenderer picks the renderer for an Accept header,
+// preferring higher quality values. A missing header gets the default.
+func negotiateArticleListRenderer(accept string) (ArticleListRenderer, error) {
+	if strings.TrimSpace(accept) == "" {
+		return articleListRenderers[0].renderer, nil
+	}
+
+	var (
+		best        ArticleListRenderer
+		bestQuality float64
+	)
+
+	for _, r := range articleListRenderers {
+		quality := acceptQuality(accept, r.mediaType)
+		if quality > bestQuality {
+			best, bestQuality = r.renderer, quality
+		}
+	}
+
+	if best == nil {
+		return nil, _errors.NotAcceptableErrorf("none of the accepted media types can be rendered")
+	}
+	return best, nil
+}
+
+// acceptQuality returns the quality value an Accept header gives mediaType,
+// taken from its most specific matching range, or 0 when it is not accepted.
+func acceptQuality(accept, mediaType string) float64 {
+	quality, specificity := 0.0, -1
+	mainType, _, _ := strings.Cut(mediaType, "/")
+
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaRange := strings.ToLower(strings.TrimSpace(params[0]))
+
+		var s int
+		switch mediaRange {
+		case mediaType:
+			s = 2
+		case mainType + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		default:
+			continue
+		}
+		if s < specificity {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range params[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(name, "q") {
+				if v, err := strconv.ParseFloat(value, 64); err == nil {
+					q = v
+				}
+			}
+		}
+
+		quality, specificity = q, s
+	}
+
+	return quality
+}
+
+func renderArticleListJSON(c echo.Context, articleList domain.ArticleList) error {
+	return Success(c, http.StatusOK, GetArticlesResponseFromDomain(articleList), &Meta{
+		Page:       articleList.Page,
+		PageSize:   articleList.PageSize,
+		TotalItems: articleList.TotalItems,
+		NextCursor: encodeArticleCursor(articleList.NextCursor),
+		PrevCursor: encodeArticleCursor(articleList.PrevCursor),
+		Facets:     FacetsResponseFromDomain(articleList.Facets),
+	})
+}
@@ -1,13 +1,23 @@
 package handler
 
 import (
+	"crypto/subtle"
 	"fmt"
 
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
 	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
 
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+	HeaderAdminToken  = "X-Admin-Token"
+	HeaderCache       = "X-Cache"
+)
+
 type Response struct {
 	Success bool        `json:"success"`
 	Data    interface{} `json:"data,omitempty"`
@@ -22,9 +32,13 @@ type Error struct {
 }
 
 type Meta struct {
-	Page       int32 `json:"page,omitempty"`
-	PageSize   int32 `json:"pageSize,omitempty"`
-	TotalItems int32 `json:"totalItems,omitempty"`
+	Page       int32  `json:"page,omitempty"`
+	PageSize   int32  `json:"pageSize,omitempty"`
+	TotalItems int32  `json:"totalItems,omitempty"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
+
+	Facets map[string][]FacetBucketResponse `json:"facets,omitempty"`
 }
 
 func Success(c echo.Context, statusCode int, data interface{}, meta *Meta) error {
@@ -69,3 +83,25 @@ func ErrorHandler() echo.HTTPErrorHandler {
 		})
 	}
 }
+
+// isAdmin reports whether the request carries the configured admin token. An
+// empty token disables admin access altogether.
+func isAdmin(c echo.Context, adminToken string) bool {
+	if adminToken == "" {
+		return false
+	}
+
+	token := c.Request().Header.Get(HeaderAdminToken)
+	return subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1
+}
+
+// setCacheHeader reports whether an article listing was served from the
+// listing cache.
+func setCacheHeader(c echo.Context, articleList domain.ArticleList) {
+	status := "MISS"
+	if articleList.Cached {
+		status = "HIT"
+	}
+
+	c.Response().Header().Set(HeaderCache, status)
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type suggestionHandler struct {
+	suggestionUseCase usecase.SuggestionUseCase
+}
+
+func InitSuggestionHandler(e *echo.Echo, suggestionUseCase usecase.SuggestionUseCase) {
+	handler := &suggestionHandler{
+		suggestionUseCase: suggestionUseCase,
+	}
+
+	e.GET("/suggest", handler.get)
+}
+
+func (h *suggestionHandler) get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	binder := new(echo.DefaultBinder)
+	req := new(GetSuggestionsRequest)
+	if err := binder.BindQueryParams(c, req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	res, err := h.suggestionUseCase.Suggest(ctx, req.SuggestionType(), req.Prefix(), req.LimitOrDefault())
+	if err != nil {
+		return err
+	}
+
+	return Success(c, http.StatusOK, GetSuggestionsResponseFromDomain(res), nil)
+}
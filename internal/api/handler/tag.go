@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type tagHandler struct {
+	tagUseCase usecase.TagUseCase
+}
+
+func InitTagHandler(e *echo.Echo, tagUseCase usecase.TagUseCase) {
+	handler := &tagHandler{
+		tagUseCase: tagUseCase,
+	}
+
+	e.GET("/tags", handler.get)
+}
+
+func (h *tagHandler) get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	binder := new(echo.DefaultBinder)
+	req := new(GetTagsRequest)
+	if err := binder.BindQueryParams(c, req); err != nil {
+		return err
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	res, err := h.tagUseCase.GetTags(ctx, req.Limit)
+	if err != nil {
+		return err
+	}
+
+	return Success(c, http.StatusOK, GetTagsResponseFromDomain(res), nil)
+}
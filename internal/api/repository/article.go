@@ -3,41 +3,106 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/ariefsibuea/articles-feed/internal/api/domain"
 	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/sync/errgroup"
 )
 
+// querier runs statements on either the pool or a transaction.
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// articleStatusExpr reports a scheduled article whose publish time has passed
+// as published, so no background job is needed to flip it.
+const articleStatusExpr = `CASE WHEN art.status = 'scheduled' AND art.publish_at <= now() THEN 'published' ELSE art.status END`
+
+// articleSearchVector is the generated tsvector column backed by the GIN index
+// idx_articles_search_vector. It holds both 'simple' and stemmed lexemes.
+const articleSearchVector = `art.search_vector`
+
+// textSearchConfigs maps article languages to their Postgres text search
+// configuration. Any other language uses 'simple'.
+var textSearchConfigs = map[string]string{
+	domain.LanguageEnglish:    "english",
+	domain.LanguageIndonesian: "indonesian",
+}
+
+func textSearchConfig(language string) string {
+	if config, ok := textSearchConfigs[language]; ok {
+		return config
+	}
+	return "simple"
+}
+
+// articleColumns selects an article joined with its author, in the order
+// expected by scanArticle.
+const articleColumns = `art.article_uuid, art.author_uuid, art.title, art.body, ` + articleStatusExpr + `,
+	art.publish_at, art.language, art.version, art.created_at, art.updated_at, art.deleted_at, aut.name,
+	ARRAY(SELECT t.name FROM article_tags at JOIN tags t ON at.tag_id = t.id WHERE at.article_uuid = art.article_uuid ORDER BY t.name)`
+
 type ArticleRepository struct {
-	dbpool *pgxpool.Pool
+	dbpool   *pgxpool.Pool
+	replicas *Replicas
 }
 
-func InitArticleRepository(dbpool *pgxpool.Pool) ArticleRepository {
+// InitArticleRepository writes to dbpool and lists articles from replicas.
+func InitArticleRepository(dbpool *pgxpool.Pool, replicas *Replicas) ArticleRepository {
 	return ArticleRepository{
-		dbpool: dbpool,
+		dbpool:   dbpool,
+		replicas: replicas,
 	}
 }
 
 func (r *ArticleRepository) Create(ctx context.Context, article domain.Article) (string, error) {
-	_, err := r.dbpool.Exec(ctx, "SET search_path to articles_feed, public")
-	if err != nil {
-		return "", _errors.ErrInvalidSearchPath
-	}
+	db := conn(ctx, r.dbpool)
 
-	query := "INSERT INTO articles (author_uuid, title, body, created_at) VALUES ($1, $2, $3, $4) RETURNING article_uuid"
+	// the first revision and the tags are written in the same statement so an
+	// article is never stored without them
+	query := `WITH art AS (
+			INSERT INTO articles (author_uuid, title, body, status, publish_at, language, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING article_uuid, author_uuid, title, body, version, updated_at
+		), rev AS (
+			INSERT INTO article_revisions (article_uuid, revision, author_uuid, title, body, created_at)
+			SELECT article_uuid, version, author_uuid, title, body, updated_at FROM art
+		), tag AS (
+			INSERT INTO tags (name) SELECT unnest($9::text[])
+			ON CONFLICT (name) DO UPDATE SET name = excluded.name
+			RETURNING id
+		), art_tag AS (
+			INSERT INTO article_tags (article_uuid, tag_id)
+			SELECT art.article_uuid, tag.id FROM art, tag
+		)
+		SELECT article_uuid FROM art`
 	args := []interface{}{
 		article.AuthorUUID,
 		article.Title,
 		article.Body,
+		article.Status,
+		nullTime(article.PublishAt),
+		article.Language,
+		article.CreatedAt,
 		article.CreatedAt,
+		article.Tags,
 	}
 
 	article_uuid := ""
-	err = r.dbpool.QueryRow(ctx, query, args...).Scan(&article_uuid)
+	err := db.QueryRow(ctx, query, args...).Scan(&article_uuid)
 	if err != nil {
 		return "", err
 	}
@@ -45,97 +110,699 @@ func (r *ArticleRepository) Create(ctx context.Context, article domain.Article)
 	return article_uuid, nil
 }
 
+func (r *ArticleRepository) GetByUUID(ctx context.Context, articleUUID string) (domain.Article, error) {
+	db := conn(ctx, r.dbpool)
+
+	query := `SELECT ` + articleColumns + `
+		FROM articles art
+		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid
+		WHERE art.article_uuid = $1 AND art.deleted_at IS NULL`
+	args := []interface{}{articleUUID}
+
+	article, err := scanArticle(db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Article{}, _errors.ErrArticleNotFound
+		}
+		return domain.Article{}, err
+	}
+
+	return article, nil
+}
+
+// Update overwrites the article, bumps its version and records the new state
+// as a revision. When expectedVersion is non-zero the update only applies if
+// the stored version still matches it.
+func (r *ArticleRepository) Update(ctx context.Context, article domain.Article, expectedVersion int32) (int32, error) {
+	db := conn(ctx, r.dbpool)
+
+	query := `WITH art AS (
+			UPDATE articles
+			SET author_uuid = $1, title = $2, body = $3, updated_at = $4, version = version + 1
+			WHERE article_uuid = $5 AND deleted_at IS NULL AND ($6 = 0 OR version = $6)
+			RETURNING article_uuid, author_uuid, title, body, version, updated_at
+		)
+		INSERT INTO article_revisions (article_uuid, revision, author_uuid, title, body, created_at)
+		SELECT article_uuid, version, author_uuid, title, body, updated_at FROM art
+		RETURNING revision`
+	args := []interface{}{
+		article.AuthorUUID,
+		article.Title,
+		article.Body,
+		article.UpdatedAt,
+		article.UUID,
+		expectedVersion,
+	}
+
+	var version int32
+	err := db.QueryRow(ctx, query, args...).Scan(&version)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, r.updateMissError(ctx, article.UUID)
+		}
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// updateMissError tells apart a missing article from a stale version after an
+// UPDATE matched no rows.
+func (r *ArticleRepository) updateMissError(ctx context.Context, articleUUID string) error {
+	var exists bool
+	err := conn(ctx, r.dbpool).QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM articles WHERE article_uuid = $1 AND deleted_at IS NULL)", articleUUID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return _errors.ErrArticleNotFound
+	}
+	return _errors.ErrArticleVersionConflict
+}
+
+func (r *ArticleRepository) Delete(ctx context.Context, articleUUID string, deletedAt time.Time) error {
+	db := conn(ctx, r.dbpool)
+
+	query := "UPDATE articles SET deleted_at = $1 WHERE article_uuid = $2 AND deleted_at IS NULL"
+	args := []interface{}{deletedAt, articleUUID}
+
+	tag, err := db.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return _errors.ErrArticleNotFound
+	}
+
+	return nil
+}
+
+// Restore clears deleted_at of an article and, as the listings change, bumps
+// its updated_at and version. Restoring an article that is not deleted is a
+// no-op.
+func (r *ArticleRepository) Restore(ctx context.Context, articleUUID string) error {
+	db := conn(ctx, r.dbpool)
+
+	query := `UPDATE articles
+		SET updated_at = CASE WHEN deleted_at IS NULL THEN updated_at ELSE now() END,
+			version = CASE WHEN deleted_at IS NULL THEN version ELSE version + 1 END,
+			deleted_at = NULL
+		WHERE article_uuid = $1`
+	args := []interface{}{articleUUID}
+
+	tag, err := db.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return _errors.ErrArticleNotFound
+	}
+
+	return nil
+}
+
 func (r *ArticleRepository) GetArticles(ctx context.Context, filter domain.ArticleFilter) (domain.ArticleList, error) {
-	_, err := r.dbpool.Exec(ctx, "SET search_path to articles_feed, public")
+	var articleList domain.ArticleList
+	err := r.replicas.read(ctx, func(pool *pgxpool.Pool) error {
+		var err error
+		articleList, err = r.getArticles(ctx, pool, filter)
+		return err
+	})
+	return articleList, err
+}
+
+// getArticles reads the page, count and facets all from pool.
+func (r *ArticleRepository) getArticles(ctx context.Context, pool *pgxpool.Pool, filter domain.ArticleFilter) (domain.ArticleList, error) {
+	db, done, err := r.searchQuerier(ctx, pool, filter)
 	if err != nil {
-		return domain.ArticleList{}, _errors.ErrInvalidSearchPath
+		return domain.ArticleList{}, err
 	}
+	defer done()
 
 	argCounter := 1
 	args := make([]interface{}, 0)
 	whereCondition := make([]string, 0)
 
-	if q := strings.TrimSpace(filter.Query); q != "" {
-		whereCondition = append(whereCondition, fmt.Sprintf(
-			"to_tsvector ('simple', coalesce(art.title, '') || ' ' || coalesce(art.body, '')) @@ plainto_tsquery('simple', $%d)", argCounter))
+	// what is listed is kept apart from what the filter matches, as articles
+	// that left the listing still count towards its Last-Modified
+	listedCondition := make([]string, 0)
+	if !filter.IncludeDeleted {
+		listedCondition = append(listedCondition, "art.deleted_at IS NULL")
+	}
+
+	// scheduled articles go live as soon as their publish time has passed
+	listedCondition = append(listedCondition, "art.status IN ('published', 'scheduled') AND art.publish_at <= now()")
+
+	tsQuery := ""
+	similarities := make([]string, 0)
+
+	if q := strings.TrimSpace(filter.Query); q != "" && filter.Fuzzy {
+		whereCondition = append(whereCondition, fmt.Sprintf("$%d <%% art.title", argCounter))
+		similarities = append(similarities, fmt.Sprintf("word_similarity($%d, art.title)", argCounter))
+		args = append(args, q)
+		argCounter++
+	} else if q != "" {
+		tsQuery, q = searchTSQuery(textSearchConfig(filter.Language), filter.SearchMode, q, argCounter)
+		whereCondition = append(whereCondition, fmt.Sprintf("%s @@ %s", articleSearchVector, tsQuery))
 		args = append(args, q)
 		argCounter++
 	}
 
-	if q := strings.TrimSpace(filter.AuthorName); q != "" {
+	if q := strings.TrimSpace(filter.AuthorName); q != "" && filter.Fuzzy {
+		whereCondition = append(whereCondition, fmt.Sprintf("$%d <%% aut.name", argCounter))
+		similarities = append(similarities, fmt.Sprintf("word_similarity($%d, aut.name)", argCounter))
+		args = append(args, q)
+		argCounter++
+	} else if q != "" {
 		whereCondition = append(whereCondition, fmt.Sprintf(
 			"to_tsvector('simple', aut.name) @@ plainto_tsquery('simple', $%d)", argCounter))
 		args = append(args, q)
 		argCounter++
 	}
 
-	whereClause := ""
+	if filter.AuthorUUID != "" {
+		whereCondition = append(whereCondition, fmt.Sprintf("art.author_uuid = $%d", argCounter))
+		args = append(args, filter.AuthorUUID)
+		argCounter++
+	}
+
+	if tags := filter.Tags; len(tags) > 0 {
+		tagQuery := fmt.Sprintf(
+			"SELECT COUNT(*) FROM article_tags at JOIN tags t ON at.tag_id = t.id WHERE at.article_uuid = art.article_uuid AND t.name = ANY($%d)", argCounter)
+		args = append(args, tags)
+		argCounter++
+
+		if filter.TagMode == domain.TagMatchAll {
+			whereCondition = append(whereCondition, fmt.Sprintf("(%s) = $%d", tagQuery, argCounter))
+			args = append(args, len(tags))
+			argCounter++
+		} else {
+			whereCondition = append(whereCondition, fmt.Sprintf("(%s) > 0", tagQuery))
+		}
+	}
+
+	if !filter.CreatedFrom.IsZero() {
+		whereCondition = append(whereCondition, fmt.Sprintf("art.created_at >= $%d", argCounter))
+		args = append(args, filter.CreatedFrom)
+		argCounter++
+	}
+
+	if !filter.CreatedTo.IsZero() {
+		whereCondition = append(whereCondition, fmt.Sprintf("art.created_at <= $%d", argCounter))
+		args = append(args, filter.CreatedTo)
+		argCounter++
+	}
+
+	matchClause := ""
 	if len(whereCondition) > 0 {
-		whereClause += " WHERE " + strings.Join(whereCondition, " AND ")
+		matchClause = " WHERE " + strings.Join(whereCondition, " AND ")
+	}
+
+	whereCondition = append(listedCondition, whereCondition...)
+	whereClause := " WHERE " + strings.Join(whereCondition, " AND ")
+
+	// facets are counted on their own connections while the page is fetched,
+	// unless the search holds a transaction: taking more connections while
+	// holding one can exhaust the pool, so they are then counted in it after
+	// the page
+	_, concurrentFacets := db.(*pgxpool.Pool)
+	facetGroup, facetCtx := errgroup.WithContext(ctx)
+	facetBuckets := make([][]domain.FacetBucket, len(filter.Facets))
+	facetArgs := slices.Clone(args)
+	if concurrentFacets {
+		for i, facet := range filter.Facets {
+			facetGroup.Go(func() error {
+				buckets, err := getFacet(facetCtx, pool, facet, whereClause, facetArgs)
+				facetBuckets[i] = buckets
+				return err
+			})
+		}
 	}
+	defer facetGroup.Wait()
 
-	countQuery := `SELECT COUNT (art.article_uuid)
+	var totalItems int32
+	if !filter.SkipCount {
+		countQuery := `SELECT COUNT (art.article_uuid)
 		FROM articles art
 		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid` + whereClause
 
-	var totalItems int32
-	err = r.dbpool.QueryRow(ctx, countQuery, args...).Scan(&totalItems)
+		err = db.QueryRow(ctx, countQuery, args...).Scan(&totalItems)
+		if err != nil {
+			return domain.ArticleList{}, searchQueryError(err)
+		}
+	}
+
+	// a scheduled article going live is not a write, so its publication
+	// counts once it has passed
+	lastModifiedQuery := `SELECT MAX(GREATEST(art.created_at, art.updated_at, art.deleted_at,
+			CASE WHEN art.publish_at <= now() THEN art.publish_at END))
+		FROM articles art
+		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid` + matchClause
+
+	lastModified := sql.NullTime{}
+	err = db.QueryRow(ctx, lastModifiedQuery, args...).Scan(&lastModified)
 	if err != nil {
-		return domain.ArticleList{}, err
+		return domain.ArticleList{}, searchQueryError(err)
+	}
+
+	// the cursor only narrows the page, so it is applied after counting
+	cursor := filter.Cursor
+	if cursor != nil {
+		comparison := "<"
+		if cursor.Backward {
+			comparison = ">"
+		}
+
+		whereCondition = append(whereCondition, fmt.Sprintf(
+			"(art.created_at, art.article_uuid) %s ($%d, $%d)", comparison, argCounter, argCounter+1))
+		args = append(args, cursor.CreatedAt, cursor.UUID)
+		argCounter += 2
+
+		whereClause = " WHERE " + strings.Join(whereCondition, " AND ")
+	}
+
+	columns := articleColumns
+	highlight := filter.Highlight != nil && tsQuery != ""
+	if highlight {
+		config := textSearchConfig(filter.Language)
+		columns += fmt.Sprintf(`,
+			ts_headline('%s', art.title, %s, $%d),
+			ts_headline('%s', coalesce(art.body, ''), %s, $%d)`,
+			config, tsQuery, argCounter, config, tsQuery, argCounter+1)
+		args = append(args, titleHeadlineOptions(filter.Highlight), snippetHeadlineOptions(filter.Highlight))
+		argCounter += 2
 	}
 
-	query := `SELECT art.article_uuid, art.title, art.body, art.created_at, aut.name
+	query := `SELECT ` + columns + `
 		FROM articles art
 		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid` + whereClause
 
-	// order by
-	query += " ORDER BY art.created_at DESC"
+	// order by, with the uuid as tiebreaker so pages never overlap; a backward
+	// cursor walks the other way and the page is reversed afterwards
+	if cursor != nil && cursor.Backward {
+		query += " ORDER BY art.created_at ASC, art.article_uuid ASC"
+	} else {
+		query += " ORDER BY " + articleOrderBy(filter.Sort, tsQuery, similarities)
+	}
 
-	// limit for page size
+	// limit for page size, fetching one extra row to know whether there is
+	// another page
 	query += fmt.Sprintf(" LIMIT $%d", argCounter)
-	args = append(args, filter.PageSize)
+	args = append(args, filter.PageSize+1)
 	argCounter += 1
 
 	// offset for page
-	query += fmt.Sprintf(" OFFSET $%d", argCounter)
-	args = append(args, filter.PageSize*(filter.Page-1))
+	if cursor == nil {
+		query += fmt.Sprintf(" OFFSET $%d", argCounter)
+		args = append(args, filter.PageSize*(filter.Page-1))
+	}
 
-	rows, err := r.dbpool.Query(ctx, query, args...)
+	rows, err := db.Query(ctx, query, args...)
 	if err != nil {
-		return domain.ArticleList{}, err
+		return domain.ArticleList{}, searchQueryError(err)
 	}
 	defer rows.Close()
 
 	articles := make([]domain.Article, 0)
 	for rows.Next() {
-		article := domain.Article{}
-		articleBody := sql.NullString{}
-		authorName := ""
-
-		err := rows.Scan(
-			&article.UUID,
-			&article.Title,
-			&articleBody,
-			&article.CreatedAt,
-			&authorName,
+		var (
+			article          domain.Article
+			highlightedTitle string
+			snippet          string
 		)
+
+		if highlight {
+			article, err = scanArticle(rows, &highlightedTitle, &snippet)
+		} else {
+			article, err = scanArticle(rows)
+		}
 		if err != nil {
 			return domain.ArticleList{}, err
 		}
 
-		article.Body = articleBody.String
-		article.AuthorName = authorName
+		if highlight {
+			article.HighlightedTitle = filter.Highlight.Mark(highlightedTitle)
+			article.Snippet = filter.Highlight.Mark(snippet)
+		}
 		articles = append(articles, article)
 	}
 
 	if rows.Err() != nil {
-		return domain.ArticleList{}, rows.Err()
+		return domain.ArticleList{}, searchQueryError(rows.Err())
+	}
+
+	hasMore := len(articles) > int(filter.PageSize)
+	if hasMore {
+		articles = articles[:filter.PageSize]
+	}
+
+	if err := facetGroup.Wait(); err != nil {
+		return domain.ArticleList{}, searchQueryError(err)
 	}
 
-	return domain.ArticleList{
-		Articles:   articles,
-		Page:       filter.Page,
-		PageSize:   filter.PageSize,
-		TotalItems: totalItems,
-	}, nil
+	if !concurrentFacets {
+		for i, facet := range filter.Facets {
+			facetBuckets[i], err = getFacet(ctx, db, facet, whereClause, facetArgs)
+			if err != nil {
+				return domain.ArticleList{}, searchQueryError(err)
+			}
+		}
+	}
+
+	articleList := domain.ArticleList{
+		Articles:     articles,
+		Page:         filter.Page,
+		PageSize:     filter.PageSize,
+		TotalItems:   totalItems,
+		LastModified: lastModified.Time.UTC(),
+	}
+
+	if len(filter.Facets) > 0 {
+		articleList.Facets = make(map[domain.ArticleFacet][]domain.FacetBucket, len(filter.Facets))
+		for i, facet := range filter.Facets {
+			articleList.Facets[facet] = facetBuckets[i]
+		}
+	}
+
+	if cursor != nil && cursor.Backward {
+		slices.Reverse(articles)
+		articleList.Page = 0
+		articleList.NextCursor = articleCursor(articles, len(articles)-1, false)
+		if hasMore {
+			articleList.PrevCursor = articleCursor(articles, 0, true)
+		}
+		return articleList, nil
+	}
+
+	// cursors follow the default order only
+	if hasMore && isDefaultArticleSort(filter.Sort) {
+		articleList.NextCursor = articleCursor(articles, len(articles)-1, false)
+	}
+	if cursor != nil {
+		articleList.Page = 0
+		articleList.PrevCursor = articleCursor(articles, 0, true)
+	}
+
+	return articleList, nil
+}
+
+// searchQuerier returns what the queries of a search on pool run on, along
+// with a func releasing it. The fuzzy threshold is a per-session setting, so
+// every query of a fuzzy search has to run in a transaction that sets it.
+func (r *ArticleRepository) searchQuerier(ctx context.Context, pool *pgxpool.Pool, filter domain.ArticleFilter) (querier, func(), error) {
+	if !filter.Fuzzy {
+		return conn(ctx, pool), func() {}, nil
+	}
+
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = tx.Exec(ctx, "SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)", strconv.FormatFloat(filter.FuzzyThreshold, 'f', -1, 64))
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, nil, err
+	}
+
+	return tx, func() { tx.Rollback(ctx) }, nil
+}
+
+// maxFacetBuckets caps the buckets returned per facet; months are the most
+// recent ones, authors and tags the ones with the most articles.
+const maxFacetBuckets = 20
+
+var articleFacetQueries = map[domain.ArticleFacet]string{
+	domain.ArticleFacetAuthor: `SELECT coalesce(aut.name, ''), COUNT(art.article_uuid)
+		FROM articles art
+		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid%s
+		GROUP BY 1 ORDER BY 2 DESC, 1 LIMIT %d`,
+	domain.ArticleFacetMonth: `SELECT to_char(art.created_at AT TIME ZONE 'UTC', 'YYYY-MM'), COUNT(art.article_uuid)
+		FROM articles art
+		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid%s
+		GROUP BY 1 ORDER BY 1 DESC LIMIT %d`,
+	domain.ArticleFacetTag: `SELECT ft.name, COUNT(art.article_uuid)
+		FROM articles art
+		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid
+		JOIN article_tags fat ON fat.article_uuid = art.article_uuid
+		JOIN tags ft ON ft.id = fat.tag_id%s
+		GROUP BY 1 ORDER BY 2 DESC, 1 LIMIT %d`,
+}
+
+// getFacet counts the articles matching whereClause per facet value.
+func getFacet(ctx context.Context, db querier, facet domain.ArticleFacet, whereClause string, args []interface{}) ([]domain.FacetBucket, error) {
+	query := fmt.Sprintf(articleFacetQueries[facet], whereClause, maxFacetBuckets)
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buckets := make([]domain.FacetBucket, 0)
+	for rows.Next() {
+		var bucket domain.FacetBucket
+		if err := rows.Scan(&bucket.Value, &bucket.Count); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, rows.Err()
+}
+
+// searchTSQuery returns the tsquery expression for the search mode, reading
+// the search text from argument argIdx, along with the value to bind there.
+// config must come from textSearchConfig, as it is inlined into the SQL.
+func searchTSQuery(config string, mode domain.SearchMode, q string, argIdx int) (string, string) {
+	switch mode {
+	case domain.SearchModeWebsearch:
+		return fmt.Sprintf("websearch_to_tsquery('%s', $%d)", config, argIdx), q
+	case domain.SearchModePrefix:
+		return fmt.Sprintf("to_tsquery('%s', $%d)", config, argIdx), prefixTSQuery(q)
+	default:
+		return fmt.Sprintf("plainto_tsquery('%s', $%d)", config, argIdx), q
+	}
+}
+
+// prefixTSQuery turns free text into a to_tsquery expression matching every
+// word as a prefix, e.g. "go* conc" becomes "go:* & conc:*". Anything but
+// letters and digits separates words, so the result is always valid syntax.
+func prefixTSQuery(q string) string {
+	words := strings.FieldsFunc(q, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, word+":*")
+	}
+
+	return strings.Join(terms, " & ")
+}
+
+// pgSyntaxErrorCode is the SQLSTATE raised by to_tsquery on malformed input.
+const pgSyntaxErrorCode = "42601"
+
+// searchQueryError reports tsquery syntax errors as bad requests instead of
+// internal errors.
+func searchQueryError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgSyntaxErrorCode {
+		return _errors.BadRequestErrorf("invalid search query: %s", pgErr.Message)
+	}
+	return err
+}
+
+// articleSortClauses whitelists the ORDER BY clause of every sort a client
+// may ask for. Relevance is built separately as it needs the query argument.
+var articleSortClauses = map[domain.ArticleSort]string{
+	domain.ArticleSortCreatedAtDesc: "art.created_at DESC, art.article_uuid DESC",
+	domain.ArticleSortCreatedAtAsc:  "art.created_at ASC, art.article_uuid ASC",
+	domain.ArticleSortTitleAsc:      "art.title ASC, art.created_at DESC, art.article_uuid DESC",
+	domain.ArticleSortTitleDesc:     "art.title DESC, art.created_at DESC, art.article_uuid DESC",
+	domain.ArticleSortAuthorAsc:     "aut.name ASC NULLS LAST, art.created_at DESC, art.article_uuid DESC",
+	domain.ArticleSortAuthorDesc:    "aut.name DESC NULLS LAST, art.created_at DESC, art.article_uuid DESC",
+}
+
+func articleOrderBy(sort domain.ArticleSort, tsQuery string, similarities []string) string {
+	if sort == domain.ArticleSortRelevance && tsQuery != "" {
+		return fmt.Sprintf(
+			"ts_rank(%s, %s) DESC, art.created_at DESC, art.article_uuid DESC",
+			articleSearchVector, tsQuery)
+	}
+
+	if sort == domain.ArticleSortSimilarity && len(similarities) > 0 {
+		return fmt.Sprintf(
+			"GREATEST(%s) DESC, art.created_at DESC, art.article_uuid DESC",
+			strings.Join(similarities, ", "))
+	}
+
+	if clause, ok := articleSortClauses[sort]; ok {
+		return clause
+	}
+	return articleSortClauses[domain.DefaultArticleSort]
+}
+
+func isDefaultArticleSort(sort domain.ArticleSort) bool {
+	return sort == "" || sort == domain.DefaultArticleSort
+}
+
+// articleCursor builds a cursor positioned at articles[i], or nil if the page
+// is empty.
+func articleCursor(articles []domain.Article, i int, backward bool) *domain.ArticleCursor {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	return &domain.ArticleCursor{
+		CreatedAt: articles[i].CreatedAt,
+		UUID:      articles[i].UUID,
+		Backward:  backward,
+	}
+}
+
+// SuggestTitles returns titles of listed articles starting with prefix, or
+// similar to it when there are too few prefix matches.
+func (r *ArticleRepository) SuggestTitles(ctx context.Context, prefix string, limit int32) ([]domain.Suggestion, error) {
+	db := conn(ctx, r.dbpool)
+
+	query := `SELECT art.article_uuid, art.title
+		FROM articles art
+		WHERE art.deleted_at IS NULL AND art.status IN ('published', 'scheduled') AND art.publish_at <= now()
+			AND (art.title ILIKE $1 || '%' OR $2 <% art.title)
+		ORDER BY art.title ILIKE $1 || '%' DESC, word_similarity($2, art.title) DESC, art.title
+		LIMIT $3`
+	args := []interface{}{escapeLike(prefix), prefix, limit}
+
+	return querySuggestions(ctx, db, query, args...)
+}
+
+// UpdateStatus moves the article to its new status and bumps its version,
+// without recording a revision as the content is unchanged.
+func (r *ArticleRepository) UpdateStatus(ctx context.Context, article domain.Article, fromStatus domain.ArticleStatus) (int32, error) {
+	db := conn(ctx, r.dbpool)
+
+	// guard on the status the transition was validated against so two
+	// concurrent transitions cannot both succeed
+	query := `UPDATE articles art
+		SET status = $1, publish_at = $2, updated_at = $3, version = version + 1
+		WHERE art.article_uuid = $4 AND art.deleted_at IS NULL
+			AND ` + articleStatusExpr + ` = $5
+		RETURNING version`
+	args := []interface{}{
+		article.Status,
+		nullTime(article.PublishAt),
+		article.UpdatedAt,
+		article.UUID,
+		fromStatus,
+	}
+
+	var version int32
+	err := db.QueryRow(ctx, query, args...).Scan(&version)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, r.updateMissError(ctx, article.UUID)
+		}
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// scanArticle scans the columns of articleColumns, followed by any extra
+// columns the caller selected into the given destinations.
+func scanArticle(row pgx.Row, extra ...interface{}) (domain.Article, error) {
+	article := domain.Article{}
+	authorUUID := sql.NullString{}
+	articleBody := sql.NullString{}
+	publishAt := sql.NullTime{}
+	deletedAt := sql.NullTime{}
+	authorName := sql.NullString{}
+
+	dest := []interface{}{
+		&article.UUID,
+		&authorUUID,
+		&article.Title,
+		&articleBody,
+		&article.Status,
+		&publishAt,
+		&article.Language,
+		&article.Version,
+		&article.CreatedAt,
+		&article.UpdatedAt,
+		&deletedAt,
+		&authorName,
+		&article.Tags,
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return domain.Article{}, err
+	}
+
+	article.AuthorUUID = authorUUID.String
+	article.Body = articleBody.String
+	// timestamptz scans in the local zone; articles are rendered in UTC
+	article.CreatedAt = article.CreatedAt.UTC()
+	article.UpdatedAt = article.UpdatedAt.UTC()
+	article.PublishAt = publishAt.Time.UTC()
+	article.DeletedAt = deletedAt.Time.UTC()
+	article.AuthorName = authorName.String
+
+	return article, nil
+}
+
+// titleHeadlineOptions highlights every match in the title, as titles are
+// short enough to return whole.
+func titleHeadlineOptions(options *domain.HighlightOptions) string {
+	return fmt.Sprintf(`StartSel="%s", StopSel="%s", HighlightAll=true`, domain.HighlightStartMark, domain.HighlightStopMark)
+}
+
+func snippetHeadlineOptions(options *domain.HighlightOptions) string {
+	return fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxFragments=%d, FragmentDelimiter=" ... "`,
+		domain.HighlightStartMark, domain.HighlightStopMark, options.MaxFragments)
+}
+
+// escapeLike escapes the LIKE wildcards in s so it matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func querySuggestions(ctx context.Context, db querier, query string, args ...interface{}) ([]domain.Suggestion, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := make([]domain.Suggestion, 0)
+	for rows.Next() {
+		suggestion := domain.Suggestion{}
+
+		err := rows.Scan(
+			&suggestion.ID,
+			&suggestion.Value,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return suggestions, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  t,
+		Valid: !t.IsZero(),
+	}
 }
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *ArticleRepository) GetRevisions(ctx context.Context, articleUUID string) ([]domain.ArticleRevision, error) {
+	db := conn(ctx, r.dbpool)
+
+	query := `SELECT rev.article_uuid, rev.revision, rev.author_uuid, rev.title, rev.body, rev.created_at, aut.name
+		FROM article_revisions rev
+		LEFT JOIN authors aut ON rev.author_uuid = aut.author_uuid
+		WHERE rev.article_uuid = $1
+		ORDER BY rev.revision DESC`
+	args := []interface{}{articleUUID}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := make([]domain.ArticleRevision, 0)
+	for rows.Next() {
+		revision, err := scanArticleRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return revisions, nil
+}
+
+func (r *ArticleRepository) GetRevision(ctx context.Context, articleUUID string, revision int32) (domain.ArticleRevision, error) {
+	db := conn(ctx, r.dbpool)
+
+	query := `SELECT rev.article_uuid, rev.revision, rev.author_uuid, rev.title, rev.body, rev.created_at, aut.name
+		FROM article_revisions rev
+		LEFT JOIN authors aut ON rev.author_uuid = aut.author_uuid
+		WHERE rev.article_uuid = $1 AND rev.revision = $2`
+	args := []interface{}{articleUUID, revision}
+
+	articleRevision, err := scanArticleRevision(db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ArticleRevision{}, _errors.ErrArticleRevisionNotFound
+		}
+		return domain.ArticleRevision{}, err
+	}
+
+	return articleRevision, nil
+}
+
+func scanArticleRevision(row pgx.Row) (domain.ArticleRevision, error) {
+	revision := domain.ArticleRevision{}
+	authorUUID := sql.NullString{}
+	body := sql.NullString{}
+	authorName := sql.NullString{}
+
+	err := row.Scan(
+		&revision.ArticleUUID,
+		&revision.Revision,
+		&authorUUID,
+		&revision.Title,
+		&body,
+		&revision.CreatedAt,
+		&authorName,
+	)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+
+	revision.AuthorUUID = authorUUID.String
+	revision.Body = body.String
+	revision.AuthorName = authorName.String
+
+	return revision, nil
+}
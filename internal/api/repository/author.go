@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/ariefsibuea/articles-feed/internal/api/domain"
 	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
@@ -20,17 +21,20 @@ func InitAuthorRepository(dbpool *pgxpool.Pool) AuthorRepository {
 	}
 }
 
-func (r *AuthorRepository) Create(ctx context.Context, author domain.Author) (string, error) {
-	_, err := r.dbpool.Exec(ctx, "SET search_path to articles_feed, public")
-	if err != nil {
-		return "", _errors.ErrInvalidSearchPath
-	}
+// Upsert creates an author, or returns the UUID of the author already holding
+// the same name, ignoring case and surrounding spaces. Concurrent calls for a
+// new name all get the same author.
+func (r *AuthorRepository) Upsert(ctx context.Context, author domain.Author) (string, error) {
+	db := conn(ctx, r.dbpool)
 
-	query := "INSERT INTO authors (name) VALUES ($1) RETURNING author_uuid"
+	// the no-op update makes RETURNING yield the existing row on conflict
+	query := `INSERT INTO authors (name) VALUES (btrim($1))
+		ON CONFLICT (lower(btrim(name))) DO UPDATE SET name = authors.name
+		RETURNING author_uuid`
 	args := []interface{}{author.Name}
 
 	author_uuid := ""
-	err = r.dbpool.QueryRow(ctx, query, args...).Scan(&author_uuid)
+	err := db.QueryRow(ctx, query, args...).Scan(&author_uuid)
 	if err != nil {
 		return "", err
 	}
@@ -39,17 +43,14 @@ func (r *AuthorRepository) Create(ctx context.Context, author domain.Author) (st
 }
 
 func (r *AuthorRepository) GetByName(ctx context.Context, name string) (domain.Author, error) {
-	_, err := r.dbpool.Exec(ctx, "SET search_path to articles_feed, public")
-	if err != nil {
-		return domain.Author{}, _errors.ErrInvalidSearchPath
-	}
+	db := conn(ctx, r.dbpool)
 
-	query := "SELECT author_uuid, name FROM authors WHERE name = $1"
+	query := "SELECT author_uuid, name FROM authors WHERE lower(btrim(name)) = lower(btrim($1))"
 	args := []interface{}{name}
 
 	author := domain.Author{}
 
-	err = r.dbpool.QueryRow(ctx, query, args...).Scan(
+	err := db.QueryRow(ctx, query, args...).Scan(
 		&author.UUID,
 		&author.Name,
 	)
@@ -62,3 +63,111 @@ func (r *AuthorRepository) GetByName(ctx context.Context, name string) (domain.A
 
 	return author, nil
 }
+
+// SuggestNames returns author names starting with prefix, or similar to it
+// when there are too few prefix matches.
+func (r *AuthorRepository) SuggestNames(ctx context.Context, prefix string, limit int32) ([]domain.Suggestion, error) {
+	db := conn(ctx, r.dbpool)
+
+	query := `SELECT author_uuid, name
+		FROM authors
+		WHERE name ILIKE $1 || '%' OR $2 <% name
+		ORDER BY name ILIKE $1 || '%' DESC, word_similarity($2, name) DESC, name
+		LIMIT $3`
+	args := []interface{}{escapeLike(prefix), prefix, limit}
+
+	return querySuggestions(ctx, db, query, args...)
+}
+
+const authorColumns = `aut.author_uuid, aut.name, COUNT(art.article_uuid), MAX(art.created_at)`
+
+// authorArticlesJoin joins the listed articles of each author, so counts
+// leave out drafts, pending and deleted articles.
+const authorArticlesJoin = `LEFT JOIN articles art ON art.author_uuid = aut.author_uuid
+		AND art.deleted_at IS NULL AND art.status IN ('published', 'scheduled') AND art.publish_at <= now()`
+
+func (r *AuthorRepository) GetByUUID(ctx context.Context, authorUUID string) (domain.Author, error) {
+	db := conn(ctx, r.dbpool)
+
+	query := `SELECT ` + authorColumns + `
+		FROM authors aut
+		` + authorArticlesJoin + `
+		WHERE aut.author_uuid = $1
+		GROUP BY aut.author_uuid, aut.name`
+	args := []interface{}{authorUUID}
+
+	author, err := scanAuthor(db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Author{}, _errors.ErrAuthorNotFound
+		}
+		return domain.Author{}, err
+	}
+
+	return author, nil
+}
+
+// GetAuthors returns a page of authors ordered by name.
+func (r *AuthorRepository) GetAuthors(ctx context.Context, filter domain.AuthorFilter) (domain.AuthorList, error) {
+	db := conn(ctx, r.dbpool)
+
+	var totalItems int32
+	err := db.QueryRow(ctx, "SELECT COUNT(author_uuid) FROM authors").Scan(&totalItems)
+	if err != nil {
+		return domain.AuthorList{}, err
+	}
+
+	query := `SELECT ` + authorColumns + `
+		FROM authors aut
+		` + authorArticlesJoin + `
+		GROUP BY aut.author_uuid, aut.name
+		ORDER BY aut.name, aut.author_uuid
+		LIMIT $1 OFFSET $2`
+	args := []interface{}{filter.PageSize, filter.PageSize * (filter.Page - 1)}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return domain.AuthorList{}, err
+	}
+	defer rows.Close()
+
+	authors := make([]domain.Author, 0)
+	for rows.Next() {
+		author, err := scanAuthor(rows)
+		if err != nil {
+			return domain.AuthorList{}, err
+		}
+		authors = append(authors, author)
+	}
+
+	if rows.Err() != nil {
+		return domain.AuthorList{}, rows.Err()
+	}
+
+	return domain.AuthorList{
+		Authors:    authors,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalItems: totalItems,
+	}, nil
+}
+
+func scanAuthor(row pgx.Row) (domain.Author, error) {
+	var (
+		author          domain.Author
+		latestArticleAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&author.UUID,
+		&author.Name,
+		&author.ArticleCount,
+		&latestArticleAt,
+	)
+	if err != nil {
+		return domain.Author{}, err
+	}
+
+	author.LatestArticleAt = latestArticleAt.Time
+	return author, nil
+}
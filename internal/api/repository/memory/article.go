@@ -0,0 +1,442 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// maxFacetBuckets caps the buckets returned per facet, as in Postgres.
+const maxFacetBuckets = 20
+
+type ArticleRepository struct {
+	store *Store
+}
+
+func InitArticleRepository(store *Store) ArticleRepository {
+	return ArticleRepository{
+		store: store,
+	}
+}
+
+func (r *ArticleRepository) Create(ctx context.Context, article domain.Article) (string, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.data.authors[article.AuthorUUID]; article.AuthorUUID != "" && !ok {
+		return "", fmt.Errorf("author %s does not exist", article.AuthorUUID)
+	}
+
+	article.UUID = uuid.NewString()
+	article.AuthorName = ""
+	article.Tags = slices.Clone(article.Tags)
+	article.Version = 1
+	article.UpdatedAt = article.CreatedAt
+	article.DeletedAt = time.Time{}
+
+	r.store.data.articles[article.UUID] = article
+	r.addRevision(article)
+
+	return article.UUID, nil
+}
+
+func (r *ArticleRepository) GetByUUID(ctx context.Context, articleUUID string) (domain.Article, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	article, ok := r.store.data.articles[articleUUID]
+	if !ok || !article.DeletedAt.IsZero() {
+		return domain.Article{}, _errors.ErrArticleNotFound
+	}
+
+	return r.store.data.withAuthor(article, time.Now()), nil
+}
+
+func (r *ArticleRepository) Update(ctx context.Context, article domain.Article, expectedVersion int32) (int32, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.data.articles[article.UUID]
+	if !ok || !existing.DeletedAt.IsZero() {
+		return 0, _errors.ErrArticleNotFound
+	}
+	if expectedVersion != 0 && existing.Version != expectedVersion {
+		return 0, _errors.ErrArticleVersionConflict
+	}
+
+	existing.AuthorUUID = article.AuthorUUID
+	existing.Title = article.Title
+	existing.Body = article.Body
+	existing.UpdatedAt = article.UpdatedAt
+	existing.Version++
+
+	r.store.data.articles[existing.UUID] = existing
+	r.addRevision(existing)
+
+	return existing.Version, nil
+}
+
+func (r *ArticleRepository) UpdateStatus(ctx context.Context, article domain.Article, fromStatus domain.ArticleStatus) (int32, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.data.articles[article.UUID]
+	if !ok || !existing.DeletedAt.IsZero() {
+		return 0, _errors.ErrArticleNotFound
+	}
+	if r.store.data.withAuthor(existing, time.Now()).Status != fromStatus {
+		return 0, _errors.ErrArticleVersionConflict
+	}
+
+	existing.Status = article.Status
+	existing.PublishAt = article.PublishAt
+	existing.UpdatedAt = article.UpdatedAt
+	existing.Version++
+
+	r.store.data.articles[existing.UUID] = existing
+	return existing.Version, nil
+}
+
+func (r *ArticleRepository) Delete(ctx context.Context, articleUUID string, deletedAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	article, ok := r.store.data.articles[articleUUID]
+	if !ok || !article.DeletedAt.IsZero() {
+		return _errors.ErrArticleNotFound
+	}
+
+	article.DeletedAt = deletedAt
+	r.store.data.articles[articleUUID] = article
+	return nil
+}
+
+func (r *ArticleRepository) Restore(ctx context.Context, articleUUID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	article, ok := r.store.data.articles[articleUUID]
+	if !ok {
+		return _errors.ErrArticleNotFound
+	}
+
+	if !article.DeletedAt.IsZero() {
+		article.DeletedAt = time.Time{}
+		article.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+		article.Version++
+	}
+	r.store.data.articles[articleUUID] = article
+	return nil
+}
+
+func (r *ArticleRepository) GetRevisions(ctx context.Context, articleUUID string) ([]domain.ArticleRevision, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	revisions := make([]domain.ArticleRevision, 0)
+	for _, rev := range slices.Backward(r.store.data.revisions[articleUUID]) {
+		rev.AuthorName = r.store.data.authors[rev.AuthorUUID].Name
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, nil
+}
+
+func (r *ArticleRepository) GetRevision(ctx context.Context, articleUUID string, revision int32) (domain.ArticleRevision, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, rev := range r.store.data.revisions[articleUUID] {
+		if rev.Revision == revision {
+			rev.AuthorName = r.store.data.authors[rev.AuthorUUID].Name
+			return rev, nil
+		}
+	}
+
+	return domain.ArticleRevision{}, _errors.ErrArticleRevisionNotFound
+}
+
+func (r *ArticleRepository) SuggestTitles(ctx context.Context, prefix string, limit int32) ([]domain.Suggestion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	now := time.Now()
+	titles := make([]domain.Suggestion, 0)
+	for _, a := range r.store.data.articles {
+		if isListed(a, now) {
+			titles = append(titles, domain.Suggestion{ID: a.UUID, Value: a.Title})
+		}
+	}
+
+	return suggest(titles, prefix, limit), nil
+}
+
+// GetArticles follows the filtering, ordering and pagination of the Postgres
+// repository. Relevance and similarity sorts fall back to the default order.
+func (r *ArticleRepository) GetArticles(ctx context.Context, filter domain.ArticleFilter) (domain.ArticleList, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	now := time.Now()
+	matches := make([]domain.Article, 0)
+	var lastModified time.Time
+	for _, a := range r.store.data.articles {
+		a = r.store.data.withAuthor(a, now)
+		if !matchesArticleFilter(a, filter) {
+			continue
+		}
+
+		// articles that left the listing still count towards its Last-Modified
+		if t := articleLastModified(a, now); t.After(lastModified) {
+			lastModified = t
+		}
+
+		if !isPublished(a, now) || (!a.DeletedAt.IsZero() && !filter.IncludeDeleted) {
+			continue
+		}
+		matches = append(matches, a)
+	}
+
+	var totalItems int32
+	if !filter.SkipCount {
+		totalItems = int32(len(matches))
+	}
+
+	var facets map[domain.ArticleFacet][]domain.FacetBucket
+	if len(filter.Facets) > 0 {
+		facets = make(map[domain.ArticleFacet][]domain.FacetBucket, len(filter.Facets))
+		for _, facet := range filter.Facets {
+			facets[facet] = articleFacetBuckets(matches, facet)
+		}
+	}
+
+	cursor := filter.Cursor
+	if cursor != nil && cursor.Backward {
+		matches = slices.DeleteFunc(matches, func(a domain.Article) bool {
+			return compareCreatedAt(a, cursor.CreatedAt, cursor.UUID) <= 0
+		})
+		slices.SortFunc(matches, func(a, b domain.Article) int {
+			return -compareArticles(a, b, domain.DefaultArticleSort)
+		})
+	} else {
+		if cursor != nil {
+			matches = slices.DeleteFunc(matches, func(a domain.Article) bool {
+				return compareCreatedAt(a, cursor.CreatedAt, cursor.UUID) >= 0
+			})
+		}
+		slices.SortFunc(matches, func(a, b domain.Article) int {
+			return compareArticles(a, b, filter.Sort)
+		})
+	}
+
+	articles := matches
+	if cursor == nil {
+		articles = matches[min(max(int(filter.PageSize)*int(filter.Page-1), 0), len(matches)):]
+	}
+
+	// keep one extra article to know whether there is another page
+	articles = slices.Clone(articles[:min(len(articles), int(filter.PageSize)+1)])
+
+	hasMore := len(articles) > int(filter.PageSize)
+	if hasMore {
+		articles = articles[:filter.PageSize]
+	}
+
+	articleList := domain.ArticleList{
+		Articles:     articles,
+		Page:         filter.Page,
+		PageSize:     filter.PageSize,
+		TotalItems:   totalItems,
+		Facets:       facets,
+		LastModified: lastModified,
+	}
+
+	if cursor != nil && cursor.Backward {
+		slices.Reverse(articles)
+		articleList.Page = 0
+		articleList.NextCursor = articleCursor(articles, len(articles)-1, false)
+		if hasMore {
+			articleList.PrevCursor = articleCursor(articles, 0, true)
+		}
+		return articleList, nil
+	}
+
+	if hasMore && (filter.Sort == "" || filter.Sort == domain.DefaultArticleSort) {
+		articleList.NextCursor = articleCursor(articles, len(articles)-1, false)
+	}
+	if cursor != nil {
+		articleList.Page = 0
+		articleList.PrevCursor = articleCursor(articles, 0, true)
+	}
+
+	return articleList, nil
+}
+
+// articleLastModified is when an article was last created, edited, deleted or
+// moved to another status, or its publish time if that has passed.
+func articleLastModified(a domain.Article, now time.Time) time.Time {
+	lastModified := a.CreatedAt
+	for _, t := range []time.Time{a.UpdatedAt, a.DeletedAt} {
+		if t.After(lastModified) {
+			lastModified = t
+		}
+	}
+	if a.PublishAt.After(lastModified) && !a.PublishAt.After(now) {
+		lastModified = a.PublishAt
+	}
+	return lastModified
+}
+
+// addRevision records the current state of an article. The caller must hold
+// the store lock.
+func (r *ArticleRepository) addRevision(article domain.Article) {
+	r.store.data.revisions[article.UUID] = append(r.store.data.revisions[article.UUID], domain.ArticleRevision{
+		ArticleUUID: article.UUID,
+		Revision:    article.Version,
+		AuthorUUID:  article.AuthorUUID,
+		Title:       article.Title,
+		Body:        article.Body,
+		CreatedAt:   article.UpdatedAt,
+	})
+}
+
+func matchesArticleFilter(a domain.Article, filter domain.ArticleFilter) bool {
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		text := a.Title
+		if !filter.Fuzzy {
+			text += " " + a.Body
+		}
+		if !containsFold(text, q) {
+			return false
+		}
+	}
+
+	if q := strings.TrimSpace(filter.AuthorName); q != "" && !containsFold(a.AuthorName, q) {
+		return false
+	}
+
+	if filter.AuthorUUID != "" && a.AuthorUUID != filter.AuthorUUID {
+		return false
+	}
+
+	if len(filter.Tags) > 0 {
+		matched := 0
+		for _, t := range filter.Tags {
+			if slices.Contains(a.Tags, t) {
+				matched++
+			}
+		}
+		if matched == 0 || (filter.TagMode == domain.TagMatchAll && matched != len(filter.Tags)) {
+			return false
+		}
+	}
+
+	if !filter.CreatedFrom.IsZero() && a.CreatedAt.Before(filter.CreatedFrom) {
+		return false
+	}
+	if !filter.CreatedTo.IsZero() && a.CreatedAt.After(filter.CreatedTo) {
+		return false
+	}
+
+	return true
+}
+
+// compareCreatedAt orders an article against a (created_at, uuid) position.
+func compareCreatedAt(a domain.Article, createdAt time.Time, articleUUID string) int {
+	if c := a.CreatedAt.Compare(createdAt); c != 0 {
+		return c
+	}
+	return strings.Compare(a.UUID, articleUUID)
+}
+
+// compareArticles orders two articles for the given sort, breaking ties by
+// newest first like the Postgres repository.
+func compareArticles(a, b domain.Article, sort domain.ArticleSort) int {
+	newestFirst := -compareCreatedAt(a, b.CreatedAt, b.UUID)
+
+	var c int
+	switch sort {
+	case domain.ArticleSortCreatedAtAsc:
+		return -newestFirst
+	case domain.ArticleSortTitleAsc:
+		c = strings.Compare(a.Title, b.Title)
+	case domain.ArticleSortTitleDesc:
+		c = strings.Compare(b.Title, a.Title)
+	case domain.ArticleSortAuthorAsc, domain.ArticleSortAuthorDesc:
+		// articles without an author come last either way
+		if (a.AuthorName == "") != (b.AuthorName == "") {
+			if a.AuthorName == "" {
+				return 1
+			}
+			return -1
+		}
+		c = strings.Compare(a.AuthorName, b.AuthorName)
+		if sort == domain.ArticleSortAuthorDesc {
+			c = -c
+		}
+	}
+
+	if c != 0 {
+		return c
+	}
+	return newestFirst
+}
+
+func articleFacetBuckets(articles []domain.Article, facet domain.ArticleFacet) []domain.FacetBucket {
+	counts := make(map[string]int32)
+	for _, a := range articles {
+		switch facet {
+		case domain.ArticleFacetAuthor:
+			counts[a.AuthorName]++
+		case domain.ArticleFacetMonth:
+			counts[a.CreatedAt.UTC().Format("2006-01")]++
+		case domain.ArticleFacetTag:
+			for _, t := range a.Tags {
+				counts[t]++
+			}
+		}
+	}
+
+	buckets := make([]domain.FacetBucket, 0, len(counts))
+	for value, count := range counts {
+		buckets = append(buckets, domain.FacetBucket{Value: value, Count: count})
+	}
+
+	slices.SortFunc(buckets, func(a, b domain.FacetBucket) int {
+		// months are listed newest first, everything else by count
+		if facet == domain.ArticleFacetMonth {
+			return strings.Compare(b.Value, a.Value)
+		}
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+
+	if len(buckets) > maxFacetBuckets {
+		buckets = buckets[:maxFacetBuckets]
+	}
+	return buckets
+}
+
+// articleCursor builds a cursor positioned at articles[i], or nil if the page
+// is empty.
+func articleCursor(articles []domain.Article, i int, backward bool) *domain.ArticleCursor {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	return &domain.ArticleCursor{
+		CreatedAt: articles[i].CreatedAt,
+		UUID:      articles[i].UUID,
+		Backward:  backward,
+	}
+}
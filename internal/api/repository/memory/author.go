@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+type AuthorRepository struct {
+	store *Store
+}
+
+func InitAuthorRepository(store *Store) AuthorRepository {
+	return AuthorRepository{
+		store: store,
+	}
+}
+
+func normalizeAuthorName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func (r *AuthorRepository) Upsert(ctx context.Context, author domain.Author) (string, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, a := range r.store.data.authors {
+		if normalizeAuthorName(a.Name) == normalizeAuthorName(author.Name) {
+			return a.UUID, nil
+		}
+	}
+
+	newAuthor := domain.Author{
+		UUID: uuid.NewString(),
+		Name: strings.TrimSpace(author.Name),
+	}
+	r.store.data.authors[newAuthor.UUID] = newAuthor
+
+	return newAuthor.UUID, nil
+}
+
+func (r *AuthorRepository) GetByName(ctx context.Context, name string) (domain.Author, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, a := range r.store.data.authors {
+		if normalizeAuthorName(a.Name) == normalizeAuthorName(name) {
+			return domain.Author{UUID: a.UUID, Name: a.Name}, nil
+		}
+	}
+
+	return domain.Author{}, _errors.ErrAuthorNotFound
+}
+
+func (r *AuthorRepository) GetByUUID(ctx context.Context, authorUUID string) (domain.Author, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	author, ok := r.store.data.authors[authorUUID]
+	if !ok {
+		return domain.Author{}, _errors.ErrAuthorNotFound
+	}
+
+	return r.withArticleStats(author, time.Now()), nil
+}
+
+func (r *AuthorRepository) GetAuthors(ctx context.Context, filter domain.AuthorFilter) (domain.AuthorList, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	now := time.Now()
+	authors := make([]domain.Author, 0, len(r.store.data.authors))
+	for _, a := range r.store.data.authors {
+		authors = append(authors, r.withArticleStats(a, now))
+	}
+
+	slices.SortFunc(authors, func(a, b domain.Author) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UUID, b.UUID)
+	})
+
+	return domain.AuthorList{
+		Authors:    paginate(authors, filter.Page, filter.PageSize),
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalItems: int32(len(authors)),
+	}, nil
+}
+
+func (r *AuthorRepository) SuggestNames(ctx context.Context, prefix string, limit int32) ([]domain.Suggestion, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	names := make([]domain.Suggestion, 0, len(r.store.data.authors))
+	for _, a := range r.store.data.authors {
+		names = append(names, domain.Suggestion{ID: a.UUID, Value: a.Name})
+	}
+
+	return suggest(names, prefix, limit), nil
+}
+
+// withArticleStats counts the listed articles of an author. The caller must
+// hold the store lock.
+func (r *AuthorRepository) withArticleStats(author domain.Author, now time.Time) domain.Author {
+	for _, a := range r.store.data.articles {
+		if a.AuthorUUID != author.UUID || !isListed(a, now) {
+			continue
+		}
+
+		author.ArticleCount++
+		if a.CreatedAt.After(author.LatestArticleAt) {
+			author.LatestArticleAt = a.CreatedAt
+		}
+	}
+	return author
+}
+
+// paginate returns the items of a 1-based page.
+func paginate[T any](items []T, page, pageSize int32) []T {
+	start := int(pageSize) * int(page-1)
+	if start < 0 || start >= len(items) {
+		return []T{}
+	}
+
+	end := min(start+int(pageSize), len(items))
+	return items[start:end]
+}
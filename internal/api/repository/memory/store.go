@@ -0,0 +1,165 @@
+// Package memory implements the repositories on plain Go maps. It is meant for
+// tests and local runs without a database: searches are case-insensitive
+// substring matches rather than full-text or trigram searches, and highlight
+// options are ignored.
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+)
+
+type txKey struct{}
+
+// Store holds the data shared by the repositories of this package.
+type Store struct {
+	mu   sync.RWMutex
+	txMu sync.Mutex
+	data storeData
+}
+
+type storeData struct {
+	articles  map[string]domain.Article
+	revisions map[string][]domain.ArticleRevision
+	authors   map[string]domain.Author
+}
+
+func InitStore() *Store {
+	return &Store{
+		data: storeData{
+			articles:  make(map[string]domain.Article),
+			revisions: make(map[string][]domain.ArticleRevision),
+			authors:   make(map[string]domain.Author),
+		},
+	}
+}
+
+// Reset removes all data from the store.
+func (s *Store) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = InitStore().data
+}
+
+func (d storeData) clone() storeData {
+	c := storeData{
+		articles:  make(map[string]domain.Article, len(d.articles)),
+		revisions: make(map[string][]domain.ArticleRevision, len(d.revisions)),
+		authors:   make(map[string]domain.Author, len(d.authors)),
+	}
+
+	for k, v := range d.articles {
+		v.Tags = slices.Clone(v.Tags)
+		c.articles[k] = v
+	}
+	for k, v := range d.revisions {
+		c.revisions[k] = slices.Clone(v)
+	}
+	for k, v := range d.authors {
+		c.authors[k] = v
+	}
+
+	return c
+}
+
+// withAuthor fills in the author name of an article and reports its status
+// the way clients see it.
+func (d storeData) withAuthor(article domain.Article, now time.Time) domain.Article {
+	article.AuthorName = d.authors[article.AuthorUUID].Name
+	article.Tags = slices.Clone(article.Tags)
+	if article.Status == domain.ArticleStatusScheduled && !article.PublishAt.After(now) {
+		article.Status = domain.ArticleStatusPublished
+	}
+	return article
+}
+
+// isListed reports whether an article shows up in listings: not deleted and
+// published.
+func isListed(article domain.Article, now time.Time) bool {
+	return article.DeletedAt.IsZero() && isPublished(article, now)
+}
+
+// isPublished reports whether an article is published or scheduled with its
+// publish time passed.
+func isPublished(article domain.Article, now time.Time) bool {
+	switch article.Status {
+	case domain.ArticleStatusPublished, domain.ArticleStatusScheduled:
+		return !article.PublishAt.IsZero() && !article.PublishAt.After(now)
+	}
+	return false
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// suggest orders values starting with prefix before those merely containing
+// it, then alphabetically, standing in for the trigram ranking of Postgres.
+func suggest(suggestions []domain.Suggestion, prefix string, limit int32) []domain.Suggestion {
+	matches := make([]domain.Suggestion, 0)
+	for _, s := range suggestions {
+		if containsFold(s.Value, prefix) {
+			matches = append(matches, s)
+		}
+	}
+
+	isPrefix := func(s domain.Suggestion) bool {
+		return strings.HasPrefix(strings.ToLower(s.Value), strings.ToLower(prefix))
+	}
+
+	slices.SortFunc(matches, func(a, b domain.Suggestion) int {
+		if isPrefix(a) != isPrefix(b) {
+			if isPrefix(a) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+
+	if len(matches) > int(limit) {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// Transactor runs repository calls as one unit of work. Transactions are
+// serialized and rolled back by restoring a snapshot, so writes made outside
+// of a transaction while one is open are lost if it rolls back.
+type Transactor struct {
+	store *Store
+}
+
+func InitTransactor(store *Store) Transactor {
+	return Transactor{
+		store: store,
+	}
+}
+
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) != nil {
+		return fn(ctx)
+	}
+
+	t.store.txMu.Lock()
+	defer t.store.txMu.Unlock()
+
+	t.store.mu.RLock()
+	snapshot := t.store.data.clone()
+	t.store.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		t.store.mu.Lock()
+		t.store.data = snapshot
+		t.store.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
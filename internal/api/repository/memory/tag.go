@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+)
+
+type TagRepository struct {
+	store *Store
+}
+
+func InitTagRepository(store *Store) TagRepository {
+	return TagRepository{
+		store: store,
+	}
+}
+
+func (r *TagRepository) GetTags(ctx context.Context, limit int32) ([]domain.Tag, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	now := time.Now()
+	counts := make(map[string]int32)
+	for _, a := range r.store.data.articles {
+		if !isListed(a, now) {
+			continue
+		}
+		for _, t := range a.Tags {
+			counts[t]++
+		}
+	}
+
+	tags := make([]domain.Tag, 0, len(counts))
+	for name, count := range counts {
+		tags = append(tags, domain.Tag{Name: name, ArticleCount: count})
+	}
+
+	slices.SortFunc(tags, func(a, b domain.Tag) int {
+		if c := cmp.Compare(b.ArticleCount, a.ArticleCount); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if limit > 0 && len(tags) > int(limit) {
+		tags = tags[:limit]
+	}
+	return tags, nil
+}
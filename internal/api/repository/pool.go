@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Schema is the Postgres schema the migrations create the tables in.
+const Schema = "articles_feed"
+
+// SetSearchPath is a pgxpool AfterConnect hook putting Schema first on the
+// search_path of every new connection, so queries use unqualified table names
+// without setting it per call.
+func SetSearchPath(ctx context.Context, conn *pgx.Conn) error {
+	_, err := conn.Exec(ctx, "SET search_path TO "+pgx.Identifier{Schema}.Sanitize()+", public")
+	return err
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
+	"github.com/ariefsibuea/articles-feed/internal/pkg/readpref"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Replicas spreads reads over the healthy read replicas, falling back to the
+// primary when there is none.
+type Replicas struct {
+	primary *pgxpool.Pool
+	pools   []*pgxpool.Pool
+	healthy []atomic.Bool
+	next    atomic.Uint64
+}
+
+// InitReplicas routes reads to pools, which may be empty to read from the
+// primary only. Replicas are healthy until a health check or a failed read
+// says otherwise.
+func InitReplicas(primary *pgxpool.Pool, pools []*pgxpool.Pool) *Replicas {
+	r := &Replicas{
+		primary: primary,
+		pools:   pools,
+		healthy: make([]atomic.Bool, len(pools)),
+	}
+
+	for i := range r.healthy {
+		r.healthy[i].Store(true)
+	}
+
+	return r
+}
+
+// CheckHealth pings every replica once, taking replicas that fail out of
+// rotation and putting back those that answer again.
+func (r *Replicas) CheckHealth(ctx context.Context, timeout time.Duration) {
+	for i, pool := range r.pools {
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		r.healthy[i].Store(pool.Ping(pingCtx) == nil)
+		cancel()
+	}
+}
+
+// WatchHealth runs CheckHealth every period until ctx is done.
+func (r *Replicas) WatchHealth(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.CheckHealth(ctx, period)
+		}
+	}
+}
+
+// read runs fn on the pool a read outside of a transaction should go to: the
+// primary when ctx asks for it, otherwise the next healthy replica. A replica
+// that fails to serve fn is taken out of rotation until the next health
+// check, and fn is retried on the primary.
+func (r *Replicas) read(ctx context.Context, fn func(pool *pgxpool.Pool) error) error {
+	idx := r.pick(ctx)
+	if idx < 0 {
+		return fn(r.primary)
+	}
+
+	err := fn(r.pools[idx])
+	if err == nil || !replicaFailed(ctx, err) {
+		return err
+	}
+
+	r.healthy[idx].Store(false)
+	return fn(r.primary)
+}
+
+// pick returns the index of the replica to read from, or -1 for the primary.
+// Reads inside a transaction already run on the primary.
+func (r *Replicas) pick(ctx context.Context) int {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok || readpref.Primary(ctx) {
+		return -1
+	}
+
+	start := r.next.Add(1)
+	for i := range r.pools {
+		idx := int((start + uint64(i)) % uint64(len(r.pools)))
+		if r.healthy[idx].Load() {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// replicaFailed tells a replica that could not be reached or dropped the
+// connection apart from a read the server rejected or the caller gave up on.
+func replicaFailed(ctx context.Context, err error) bool {
+	var (
+		pgErr     *pgconn.PgError
+		customErr _errors.CustomError
+	)
+	return ctx.Err() == nil && !errors.As(err, &pgErr) && !errors.As(err, &customErr)
+}
@@ -0,0 +1,834 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// articleStatusExpr reports a scheduled article whose publish time has passed
+// as published, so no background job is needed to flip it. Like every query
+// using it, it reads the current time from argument ?1.
+const articleStatusExpr = `CASE WHEN art.status = 'scheduled' AND art.publish_at <= ?1 THEN 'published' ELSE art.status END`
+
+// articleColumns selects an article joined with its author, in the order
+// expected by scanArticle. Tags are selected as a JSON array.
+const articleColumns = `art.article_uuid, art.author_uuid, art.title, art.body, ` + articleStatusExpr + `,
+	art.publish_at, art.language, art.version, art.created_at, art.updated_at, art.deleted_at, aut.name,
+	(SELECT json_group_array(name) FROM (SELECT t.name FROM article_tags at JOIN tags t ON at.tag_id = t.id WHERE at.article_uuid = art.article_uuid ORDER BY t.name))`
+
+type ArticleRepository struct {
+	db *sql.DB
+}
+
+func InitArticleRepository(db *sql.DB) ArticleRepository {
+	return ArticleRepository{
+		db: db,
+	}
+}
+
+func (r *ArticleRepository) Create(ctx context.Context, article domain.Article) (string, error) {
+	article_uuid := uuid.NewString()
+
+	// the first revision and the tags are written in the same transaction so
+	// an article is never stored without them
+	err := withinTransaction(ctx, r.db, func(ctx context.Context) error {
+		db := conn(ctx, r.db)
+
+		query := `INSERT INTO articles (article_uuid, author_uuid, title, body, status, publish_at, language, created_at, updated_at)
+			VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?8)`
+		args := []interface{}{
+			article_uuid,
+			nullString(article.AuthorUUID),
+			article.Title,
+			article.Body,
+			article.Status,
+			nullTime(article.PublishAt),
+			article.Language,
+			formatTime(article.CreatedAt),
+		}
+
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+
+		query = `INSERT INTO article_revisions (article_uuid, revision, author_uuid, title, body, created_at)
+			SELECT article_uuid, version, author_uuid, title, body, updated_at FROM articles WHERE article_uuid = ?1`
+		if _, err := db.ExecContext(ctx, query, article_uuid); err != nil {
+			return err
+		}
+
+		for _, tag := range article.Tags {
+			if _, err := db.ExecContext(ctx, "INSERT INTO tags (name) VALUES (?1) ON CONFLICT (name) DO NOTHING", tag); err != nil {
+				return err
+			}
+
+			query = "INSERT INTO article_tags (article_uuid, tag_id) SELECT ?1, id FROM tags WHERE name = ?2"
+			if _, err := db.ExecContext(ctx, query, article_uuid, tag); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return article_uuid, nil
+}
+
+func (r *ArticleRepository) GetByUUID(ctx context.Context, articleUUID string) (domain.Article, error) {
+	db := conn(ctx, r.db)
+
+	query := `SELECT ` + articleColumns + `
+		FROM articles art
+		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid
+		WHERE art.article_uuid = ?2 AND art.deleted_at IS NULL`
+	args := []interface{}{formatTime(time.Now()), articleUUID}
+
+	article, err := scanArticle(db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Article{}, _errors.ErrArticleNotFound
+		}
+		return domain.Article{}, err
+	}
+
+	return article, nil
+}
+
+// Update overwrites the article, bumps its version and records the new state
+// as a revision. When expectedVersion is non-zero the update only applies if
+// the stored version still matches it.
+func (r *ArticleRepository) Update(ctx context.Context, article domain.Article, expectedVersion int32) (int32, error) {
+	var version int32
+
+	err := withinTransaction(ctx, r.db, func(ctx context.Context) error {
+		db := conn(ctx, r.db)
+
+		query := `UPDATE articles
+			SET author_uuid = ?1, title = ?2, body = ?3, updated_at = ?4, version = version + 1
+			WHERE article_uuid = ?5 AND deleted_at IS NULL AND (?6 = 0 OR version = ?6)
+			RETURNING version`
+		args := []interface{}{
+			nullString(article.AuthorUUID),
+			article.Title,
+			article.Body,
+			formatTime(article.UpdatedAt),
+			article.UUID,
+			expectedVersion,
+		}
+
+		err := db.QueryRowContext(ctx, query, args...).Scan(&version)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return r.updateMissError(ctx, article.UUID)
+			}
+			return err
+		}
+
+		query = `INSERT INTO article_revisions (article_uuid, revision, author_uuid, title, body, created_at)
+			SELECT article_uuid, version, author_uuid, title, body, updated_at FROM articles WHERE article_uuid = ?1`
+		_, err = db.ExecContext(ctx, query, article.UUID)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// updateMissError tells apart a missing article from a stale version after an
+// UPDATE matched no rows.
+func (r *ArticleRepository) updateMissError(ctx context.Context, articleUUID string) error {
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM articles WHERE article_uuid = ?1 AND deleted_at IS NULL)", articleUUID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return _errors.ErrArticleNotFound
+	}
+	return _errors.ErrArticleVersionConflict
+}
+
+func (r *ArticleRepository) Delete(ctx context.Context, articleUUID string, deletedAt time.Time) error {
+	db := conn(ctx, r.db)
+
+	query := "UPDATE articles SET deleted_at = ?1 WHERE article_uuid = ?2 AND deleted_at IS NULL"
+	args := []interface{}{formatTime(deletedAt), articleUUID}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return requireRowsAffected(result, _errors.ErrArticleNotFound)
+}
+
+// Restore clears deleted_at of an article and, as the listings change, bumps
+// its updated_at and version. Restoring an article that is not deleted is a
+// no-op.
+func (r *ArticleRepository) Restore(ctx context.Context, articleUUID string) error {
+	db := conn(ctx, r.db)
+
+	query := `UPDATE articles
+		SET updated_at = CASE WHEN deleted_at IS NULL THEN updated_at ELSE ?2 END,
+			version = CASE WHEN deleted_at IS NULL THEN version ELSE version + 1 END,
+			deleted_at = NULL
+		WHERE article_uuid = ?1`
+	args := []interface{}{articleUUID, formatTime(time.Now())}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return requireRowsAffected(result, _errors.ErrArticleNotFound)
+}
+
+// UpdateStatus moves the article to its new status and bumps its version,
+// without recording a revision as the content is unchanged.
+func (r *ArticleRepository) UpdateStatus(ctx context.Context, article domain.Article, fromStatus domain.ArticleStatus) (int32, error) {
+	db := conn(ctx, r.db)
+
+	// guard on the status the transition was validated against so two
+	// concurrent transitions cannot both succeed
+	query := `UPDATE articles AS art
+		SET status = ?2, publish_at = ?3, updated_at = ?4, version = version + 1
+		WHERE art.article_uuid = ?5 AND art.deleted_at IS NULL
+			AND ` + articleStatusExpr + ` = ?6
+		RETURNING version`
+	args := []interface{}{
+		formatTime(time.Now()),
+		article.Status,
+		nullTime(article.PublishAt),
+		formatTime(article.UpdatedAt),
+		article.UUID,
+		fromStatus,
+	}
+
+	var version int32
+	err := db.QueryRowContext(ctx, query, args...).Scan(&version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, r.updateMissError(ctx, article.UUID)
+		}
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (r *ArticleRepository) GetArticles(ctx context.Context, filter domain.ArticleFilter) (domain.ArticleList, error) {
+	db := conn(ctx, r.db)
+
+	argCounter := 2
+	args := []interface{}{formatTime(time.Now())}
+	whereCondition := make([]string, 0)
+
+	// what is listed is kept apart from what the filter matches, as articles
+	// that left the listing still count towards its Last-Modified
+	listedCondition := make([]string, 0)
+	if !filter.IncludeDeleted {
+		listedCondition = append(listedCondition, "art.deleted_at IS NULL")
+	}
+
+	// scheduled articles go live as soon as their publish time has passed
+	listedCondition = append(listedCondition, "art.status IN ('published', 'scheduled') AND art.publish_at <= ?1")
+
+	// ftsArg is the argument holding the FTS5 query of a full-text search,
+	// which ranking and highlighting match against again
+	ftsArg := 0
+
+	// SQLite has no trigram similarity, so a fuzzy search falls back to a
+	// substring match
+	if q := strings.TrimSpace(filter.Query); q != "" && filter.Fuzzy {
+		whereCondition = append(whereCondition, fmt.Sprintf(`art.title LIKE '%%' || ?%d || '%%' ESCAPE '\'`, argCounter))
+		args = append(args, escapeLike(q))
+		argCounter++
+	} else if q != "" {
+		ftsArg = argCounter
+		whereCondition = append(whereCondition, fmt.Sprintf(
+			"art.id IN (SELECT rowid FROM articles_fts WHERE articles_fts MATCH ?%d)", argCounter))
+		args = append(args, searchFTSQuery(filter.SearchMode, q))
+		argCounter++
+	}
+
+	if q := strings.TrimSpace(filter.AuthorName); q != "" && filter.Fuzzy {
+		whereCondition = append(whereCondition, fmt.Sprintf(`aut.name LIKE '%%' || ?%d || '%%' ESCAPE '\'`, argCounter))
+		args = append(args, escapeLike(q))
+		argCounter++
+	} else if q != "" {
+		whereCondition = append(whereCondition, fmt.Sprintf(
+			"aut.id IN (SELECT rowid FROM authors_fts WHERE authors_fts MATCH ?%d)", argCounter))
+		args = append(args, searchFTSQuery(domain.SearchModePlain, q))
+		argCounter++
+	}
+
+	if filter.AuthorUUID != "" {
+		whereCondition = append(whereCondition, fmt.Sprintf("art.author_uuid = ?%d", argCounter))
+		args = append(args, filter.AuthorUUID)
+		argCounter++
+	}
+
+	if tags := filter.Tags; len(tags) > 0 {
+		tagNames, err := json.Marshal(tags)
+		if err != nil {
+			return domain.ArticleList{}, err
+		}
+
+		tagQuery := fmt.Sprintf(
+			"SELECT COUNT(*) FROM article_tags at JOIN tags t ON at.tag_id = t.id WHERE at.article_uuid = art.article_uuid AND t.name IN (SELECT value FROM json_each(?%d))", argCounter)
+		args = append(args, string(tagNames))
+		argCounter++
+
+		if filter.TagMode == domain.TagMatchAll {
+			whereCondition = append(whereCondition, fmt.Sprintf("(%s) = ?%d", tagQuery, argCounter))
+			args = append(args, len(tags))
+			argCounter++
+		} else {
+			whereCondition = append(whereCondition, fmt.Sprintf("(%s) > 0", tagQuery))
+		}
+	}
+
+	if !filter.CreatedFrom.IsZero() {
+		whereCondition = append(whereCondition, fmt.Sprintf("art.created_at >= ?%d", argCounter))
+		args = append(args, formatTime(filter.CreatedFrom))
+		argCounter++
+	}
+
+	if !filter.CreatedTo.IsZero() {
+		whereCondition = append(whereCondition, fmt.Sprintf("art.created_at <= ?%d", argCounter))
+		args = append(args, formatTime(filter.CreatedTo))
+		argCounter++
+	}
+
+	matchClause := ""
+	if len(whereCondition) > 0 {
+		matchClause = " WHERE " + strings.Join(whereCondition, " AND ")
+	}
+
+	whereCondition = append(listedCondition, whereCondition...)
+	whereClause := " WHERE " + strings.Join(whereCondition, " AND ")
+
+	// a single database file gains nothing from counting facets concurrently
+	facetBuckets := make([][]domain.FacetBucket, len(filter.Facets))
+	for i, facet := range filter.Facets {
+		buckets, err := getFacet(ctx, db, facet, whereClause, args)
+		if err != nil {
+			return domain.ArticleList{}, searchQueryError(err)
+		}
+		facetBuckets[i] = buckets
+	}
+
+	var totalItems int32
+	if !filter.SkipCount {
+		countQuery := `SELECT COUNT(art.article_uuid)
+		FROM articles art
+		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid` + whereClause
+
+		err := db.QueryRowContext(ctx, countQuery, args...).Scan(&totalItems)
+		if err != nil {
+			return domain.ArticleList{}, searchQueryError(err)
+		}
+	}
+
+	// a scheduled article going live is not a write, so its publication
+	// counts once it has passed. The multi-argument max() is NULL as soon as
+	// one argument is, hence the empty strings.
+	lastModifiedQuery := `SELECT max(max(art.created_at, art.updated_at, coalesce(art.deleted_at, ''),
+			CASE WHEN art.publish_at <= ?1 THEN art.publish_at ELSE '' END))
+		FROM articles art
+		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid` + matchClause
+
+	var lastModifiedText sql.NullString
+	err := db.QueryRowContext(ctx, lastModifiedQuery, args...).Scan(&lastModifiedText)
+	if err != nil {
+		return domain.ArticleList{}, searchQueryError(err)
+	}
+
+	lastModified, err := parseTime(lastModifiedText)
+	if err != nil {
+		return domain.ArticleList{}, err
+	}
+
+	// the cursor only narrows the page, so it is applied after counting
+	cursor := filter.Cursor
+	if cursor != nil {
+		comparison := "<"
+		if cursor.Backward {
+			comparison = ">"
+		}
+
+		whereCondition = append(whereCondition, fmt.Sprintf(
+			"(art.created_at, art.article_uuid) %s (?%d, ?%d)", comparison, argCounter, argCounter+1))
+		args = append(args, formatTime(cursor.CreatedAt), cursor.UUID)
+		argCounter += 2
+
+		whereClause = " WHERE " + strings.Join(whereCondition, " AND ")
+	}
+
+	// FTS5 snippets hold a single fragment, so MaxFragments is not honoured
+	columns := articleColumns
+	highlight := filter.Highlight != nil && ftsArg != 0
+	if highlight {
+		columns += fmt.Sprintf(`,
+			(SELECT highlight(articles_fts, 0, ?%[2]d, ?%[3]d) FROM articles_fts WHERE articles_fts MATCH ?%[1]d AND rowid = art.id),
+			(SELECT snippet(articles_fts, 1, ?%[2]d, ?%[3]d, ' ... ', 32) FROM articles_fts WHERE articles_fts MATCH ?%[1]d AND rowid = art.id)`,
+			ftsArg, argCounter, argCounter+1)
+		args = append(args, domain.HighlightStartMark, domain.HighlightStopMark)
+		argCounter += 2
+	}
+
+	query := `SELECT ` + columns + `
+		FROM articles art
+		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid` + whereClause
+
+	// order by, with the uuid as tiebreaker so pages never overlap; a backward
+	// cursor walks the other way and the page is reversed afterwards
+	if cursor != nil && cursor.Backward {
+		query += " ORDER BY art.created_at ASC, art.article_uuid ASC"
+	} else {
+		query += " ORDER BY " + articleOrderBy(filter.Sort, ftsArg)
+	}
+
+	// limit for page size, fetching one extra row to know whether there is
+	// another page
+	query += fmt.Sprintf(" LIMIT ?%d", argCounter)
+	args = append(args, filter.PageSize+1)
+	argCounter += 1
+
+	// offset for page
+	if cursor == nil {
+		query += fmt.Sprintf(" OFFSET ?%d", argCounter)
+		args = append(args, filter.PageSize*(filter.Page-1))
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return domain.ArticleList{}, searchQueryError(err)
+	}
+	defer rows.Close()
+
+	articles := make([]domain.Article, 0)
+	for rows.Next() {
+		var (
+			article          domain.Article
+			highlightedTitle sql.NullString
+			snippet          sql.NullString
+		)
+
+		if highlight {
+			article, err = scanArticle(rows, &highlightedTitle, &snippet)
+		} else {
+			article, err = scanArticle(rows)
+		}
+		if err != nil {
+			return domain.ArticleList{}, err
+		}
+
+		if highlight {
+			article.HighlightedTitle = filter.Highlight.Mark(highlightedTitle.String)
+			article.Snippet = filter.Highlight.Mark(snippet.String)
+		}
+		articles = append(articles, article)
+	}
+
+	if rows.Err() != nil {
+		return domain.ArticleList{}, searchQueryError(rows.Err())
+	}
+
+	hasMore := len(articles) > int(filter.PageSize)
+	if hasMore {
+		articles = articles[:filter.PageSize]
+	}
+
+	articleList := domain.ArticleList{
+		Articles:     articles,
+		Page:         filter.Page,
+		PageSize:     filter.PageSize,
+		TotalItems:   totalItems,
+		LastModified: lastModified,
+	}
+
+	if len(filter.Facets) > 0 {
+		articleList.Facets = make(map[domain.ArticleFacet][]domain.FacetBucket, len(filter.Facets))
+		for i, facet := range filter.Facets {
+			articleList.Facets[facet] = facetBuckets[i]
+		}
+	}
+
+	if cursor != nil && cursor.Backward {
+		slices.Reverse(articles)
+		articleList.Page = 0
+		articleList.NextCursor = articleCursor(articles, len(articles)-1, false)
+		if hasMore {
+			articleList.PrevCursor = articleCursor(articles, 0, true)
+		}
+		return articleList, nil
+	}
+
+	// cursors follow the default order only
+	if hasMore && isDefaultArticleSort(filter.Sort) {
+		articleList.NextCursor = articleCursor(articles, len(articles)-1, false)
+	}
+	if cursor != nil {
+		articleList.Page = 0
+		articleList.PrevCursor = articleCursor(articles, 0, true)
+	}
+
+	return articleList, nil
+}
+
+// maxFacetBuckets caps the buckets returned per facet; months are the most
+// recent ones, authors and tags the ones with the most articles.
+const maxFacetBuckets = 20
+
+var articleFacetQueries = map[domain.ArticleFacet]string{
+	domain.ArticleFacetAuthor: `SELECT coalesce(aut.name, ''), COUNT(art.article_uuid)
+		FROM articles art
+		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid%s
+		GROUP BY 1 ORDER BY 2 DESC, 1 LIMIT %d`,
+	domain.ArticleFacetMonth: `SELECT substr(art.created_at, 1, 7), COUNT(art.article_uuid)
+		FROM articles art
+		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid%s
+		GROUP BY 1 ORDER BY 1 DESC LIMIT %d`,
+	domain.ArticleFacetTag: `SELECT ft.name, COUNT(art.article_uuid)
+		FROM articles art
+		LEFT JOIN authors aut ON art.author_uuid = aut.author_uuid
+		JOIN article_tags fat ON fat.article_uuid = art.article_uuid
+		JOIN tags ft ON ft.id = fat.tag_id%s
+		GROUP BY 1 ORDER BY 2 DESC, 1 LIMIT %d`,
+}
+
+// getFacet counts the articles matching whereClause per facet value.
+func getFacet(ctx context.Context, db querier, facet domain.ArticleFacet, whereClause string, args []interface{}) ([]domain.FacetBucket, error) {
+	query := fmt.Sprintf(articleFacetQueries[facet], whereClause, maxFacetBuckets)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buckets := make([]domain.FacetBucket, 0)
+	for rows.Next() {
+		var bucket domain.FacetBucket
+		if err := rows.Scan(&bucket.Value, &bucket.Count); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, rows.Err()
+}
+
+// searchFTSQuery turns the search text into an FTS5 query for the search
+// mode. Every word is quoted, so the result is valid syntax whatever the
+// input; text without any word yields a query matching nothing.
+func searchFTSQuery(mode domain.SearchMode, q string) string {
+	switch mode {
+	case domain.SearchModeWebsearch:
+		return websearchFTSQuery(q)
+	case domain.SearchModePrefix:
+		terms := make([]string, 0)
+		for _, word := range ftsWords(q) {
+			terms = append(terms, quoteFTS(word)+"*")
+		}
+		return joinFTSTerms(terms, " ")
+	default:
+		terms := make([]string, 0)
+		for _, word := range ftsWords(q) {
+			terms = append(terms, quoteFTS(word))
+		}
+		return joinFTSTerms(terms, " ")
+	}
+}
+
+// websearchFTSQuery follows the websearch_to_tsquery syntax of Postgres:
+// "quoted text" is a phrase, a leading - excludes a word and "or" between
+// words matches either.
+func websearchFTSQuery(q string) string {
+	var (
+		groups   [][]string
+		excluded []string
+		orNext   bool
+	)
+
+	for _, token := range websearchTokens(q) {
+		if !token.phrase && strings.EqualFold(token.text, "or") {
+			orNext = len(groups) > 0
+			continue
+		}
+
+		words := ftsWords(strings.TrimPrefix(token.text, "-"))
+		if len(words) == 0 {
+			continue
+		}
+
+		term := quoteFTS(strings.Join(words, " "))
+		switch {
+		case !token.phrase && strings.HasPrefix(token.text, "-"):
+			excluded = append(excluded, term)
+		case orNext:
+			groups[len(groups)-1] = append(groups[len(groups)-1], term)
+		default:
+			groups = append(groups, []string{term})
+		}
+		orNext = false
+	}
+
+	terms := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if len(group) == 1 {
+			terms = append(terms, group[0])
+		} else {
+			terms = append(terms, "("+strings.Join(group, " OR ")+")")
+		}
+	}
+
+	query := joinFTSTerms(terms, " AND ")
+	if query == ftsMatchNothing {
+		return query
+	}
+	for _, term := range excluded {
+		query += " NOT " + term
+	}
+	return query
+}
+
+type websearchToken struct {
+	text   string
+	phrase bool
+}
+
+// websearchTokens splits q on spaces, keeping double-quoted text together.
+func websearchTokens(q string) []websearchToken {
+	tokens := make([]websearchToken, 0)
+	for i, part := range strings.Split(q, `"`) {
+		if i%2 == 1 {
+			tokens = append(tokens, websearchToken{text: part, phrase: true})
+			continue
+		}
+		for _, field := range strings.Fields(part) {
+			tokens = append(tokens, websearchToken{text: field})
+		}
+	}
+	return tokens
+}
+
+func ftsWords(q string) []string {
+	return strings.FieldsFunc(q, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func quoteFTS(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// ftsMatchNothing is a phrase no tokenizer produces, standing in for a search
+// without words.
+const ftsMatchNothing = `""`
+
+func joinFTSTerms(terms []string, sep string) string {
+	if len(terms) == 0 {
+		return ftsMatchNothing
+	}
+	return strings.Join(terms, sep)
+}
+
+// searchQueryError reports FTS5 query errors as bad requests instead of
+// internal errors.
+func searchQueryError(err error) error {
+	if err != nil && strings.HasPrefix(err.Error(), "fts5:") {
+		return _errors.BadRequestErrorf("invalid search query: %s", err.Error())
+	}
+	return err
+}
+
+// articleSortClauses whitelists the ORDER BY clause of every sort a client
+// may ask for. Relevance is built separately as it needs the query argument;
+// similarity needs trigram matching and falls back to the default order.
+var articleSortClauses = map[domain.ArticleSort]string{
+	domain.ArticleSortCreatedAtDesc: "art.created_at DESC, art.article_uuid DESC",
+	domain.ArticleSortCreatedAtAsc:  "art.created_at ASC, art.article_uuid ASC",
+	domain.ArticleSortTitleAsc:      "art.title ASC, art.created_at DESC, art.article_uuid DESC",
+	domain.ArticleSortTitleDesc:     "art.title DESC, art.created_at DESC, art.article_uuid DESC",
+	domain.ArticleSortAuthorAsc:     "aut.name ASC NULLS LAST, art.created_at DESC, art.article_uuid DESC",
+	domain.ArticleSortAuthorDesc:    "aut.name DESC NULLS LAST, art.created_at DESC, art.article_uuid DESC",
+}
+
+func articleOrderBy(sort domain.ArticleSort, ftsArg int) string {
+	// bm25 scores better matches lower
+	if sort == domain.ArticleSortRelevance && ftsArg != 0 {
+		return fmt.Sprintf(
+			"(SELECT bm25(articles_fts) FROM articles_fts WHERE articles_fts MATCH ?%d AND rowid = art.id) ASC, art.created_at DESC, art.article_uuid DESC",
+			ftsArg)
+	}
+
+	if clause, ok := articleSortClauses[sort]; ok {
+		return clause
+	}
+	return articleSortClauses[domain.DefaultArticleSort]
+}
+
+func isDefaultArticleSort(sort domain.ArticleSort) bool {
+	return sort == "" || sort == domain.DefaultArticleSort
+}
+
+// articleCursor builds a cursor positioned at articles[i], or nil if the page
+// is empty.
+func articleCursor(articles []domain.Article, i int, backward bool) *domain.ArticleCursor {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	return &domain.ArticleCursor{
+		CreatedAt: articles[i].CreatedAt,
+		UUID:      articles[i].UUID,
+		Backward:  backward,
+	}
+}
+
+// SuggestTitles returns titles of listed articles starting with prefix,
+// followed by titles containing it.
+func (r *ArticleRepository) SuggestTitles(ctx context.Context, prefix string, limit int32) ([]domain.Suggestion, error) {
+	db := conn(ctx, r.db)
+
+	query := `SELECT art.article_uuid, art.title
+		FROM articles art
+		WHERE art.deleted_at IS NULL AND art.status IN ('published', 'scheduled') AND art.publish_at <= ?1
+			AND art.title LIKE '%' || ?2 || '%' ESCAPE '\'
+		ORDER BY art.title LIKE ?2 || '%' ESCAPE '\' DESC, art.title
+		LIMIT ?3`
+	args := []interface{}{formatTime(time.Now()), escapeLike(prefix), limit}
+
+	return querySuggestions(ctx, db, query, args...)
+}
+
+// scanArticle scans the columns of articleColumns, followed by any extra
+// columns the caller selected into the given destinations.
+func scanArticle(row scanner, extra ...interface{}) (domain.Article, error) {
+	article := domain.Article{}
+	authorUUID := sql.NullString{}
+	createdAt := sql.NullString{}
+	updatedAt := sql.NullString{}
+	publishAt := sql.NullString{}
+	deletedAt := sql.NullString{}
+	authorName := sql.NullString{}
+	tags := ""
+
+	dest := []interface{}{
+		&article.UUID,
+		&authorUUID,
+		&article.Title,
+		&article.Body,
+		&article.Status,
+		&publishAt,
+		&article.Language,
+		&article.Version,
+		&createdAt,
+		&updatedAt,
+		&deletedAt,
+		&authorName,
+		&tags,
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return domain.Article{}, err
+	}
+
+	article.AuthorUUID = authorUUID.String
+	article.AuthorName = authorName.String
+
+	if err := json.Unmarshal([]byte(tags), &article.Tags); err != nil {
+		return domain.Article{}, err
+	}
+
+	for _, t := range []struct {
+		dest  *time.Time
+		value sql.NullString
+	}{
+		{&article.CreatedAt, createdAt},
+		{&article.UpdatedAt, updatedAt},
+		{&article.PublishAt, publishAt},
+		{&article.DeletedAt, deletedAt},
+	} {
+		if *t.dest, err = parseTime(t.value); err != nil {
+			return domain.Article{}, err
+		}
+	}
+
+	return article, nil
+}
+
+// escapeLike escapes the LIKE wildcards in s so it matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func querySuggestions(ctx context.Context, db querier, query string, args ...interface{}) ([]domain.Suggestion, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := make([]domain.Suggestion, 0)
+	for rows.Next() {
+		suggestion := domain.Suggestion{}
+
+		err := rows.Scan(
+			&suggestion.ID,
+			&suggestion.Value,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return suggestions, nil
+}
+
+func requireRowsAffected(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// nullString stores an empty string as NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
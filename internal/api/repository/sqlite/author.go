@@ -0,0 +1,183 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+type AuthorRepository struct {
+	db *sql.DB
+}
+
+func InitAuthorRepository(db *sql.DB) AuthorRepository {
+	return AuthorRepository{
+		db: db,
+	}
+}
+
+func normalizeAuthorName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Upsert creates an author, or returns the UUID of the author already holding
+// the same name, ignoring case and surrounding spaces.
+func (r *AuthorRepository) Upsert(ctx context.Context, author domain.Author) (string, error) {
+	db := conn(ctx, r.db)
+
+	// the no-op update makes RETURNING yield the existing row on conflict
+	query := `INSERT INTO authors (author_uuid, name, normalized_name) VALUES (?1, ?2, ?3)
+		ON CONFLICT (normalized_name) DO UPDATE SET name = authors.name
+		RETURNING author_uuid`
+	args := []interface{}{uuid.NewString(), strings.TrimSpace(author.Name), normalizeAuthorName(author.Name)}
+
+	author_uuid := ""
+	err := db.QueryRowContext(ctx, query, args...).Scan(&author_uuid)
+	if err != nil {
+		return "", err
+	}
+
+	return author_uuid, nil
+}
+
+func (r *AuthorRepository) GetByName(ctx context.Context, name string) (domain.Author, error) {
+	db := conn(ctx, r.db)
+
+	query := "SELECT author_uuid, name FROM authors WHERE normalized_name = ?1"
+	args := []interface{}{normalizeAuthorName(name)}
+
+	author := domain.Author{}
+
+	err := db.QueryRowContext(ctx, query, args...).Scan(
+		&author.UUID,
+		&author.Name,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Author{}, _errors.ErrAuthorNotFound
+		}
+		return domain.Author{}, err
+	}
+
+	return author, nil
+}
+
+// SuggestNames returns author names starting with prefix, followed by names
+// containing it. SQLite has no trigram similarity, so misspelled prefixes
+// find nothing.
+func (r *AuthorRepository) SuggestNames(ctx context.Context, prefix string, limit int32) ([]domain.Suggestion, error) {
+	db := conn(ctx, r.db)
+
+	query := `SELECT author_uuid, name
+		FROM authors
+		WHERE name LIKE '%' || ?1 || '%' ESCAPE '\'
+		ORDER BY name LIKE ?1 || '%' ESCAPE '\' DESC, name
+		LIMIT ?2`
+	args := []interface{}{escapeLike(prefix), limit}
+
+	return querySuggestions(ctx, db, query, args...)
+}
+
+const authorColumns = `aut.author_uuid, aut.name, COUNT(art.article_uuid), MAX(art.created_at)`
+
+// authorArticlesJoin joins the listed articles of each author, so counts
+// leave out drafts, pending and deleted articles. It reads the current time
+// from argument ?1.
+const authorArticlesJoin = `LEFT JOIN articles art ON art.author_uuid = aut.author_uuid
+		AND art.deleted_at IS NULL AND art.status IN ('published', 'scheduled') AND art.publish_at <= ?1`
+
+func (r *AuthorRepository) GetByUUID(ctx context.Context, authorUUID string) (domain.Author, error) {
+	db := conn(ctx, r.db)
+
+	query := `SELECT ` + authorColumns + `
+		FROM authors aut
+		` + authorArticlesJoin + `
+		WHERE aut.author_uuid = ?2
+		GROUP BY aut.author_uuid, aut.name`
+	args := []interface{}{formatTime(time.Now()), authorUUID}
+
+	author, err := scanAuthor(db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Author{}, _errors.ErrAuthorNotFound
+		}
+		return domain.Author{}, err
+	}
+
+	return author, nil
+}
+
+// GetAuthors returns a page of authors ordered by name.
+func (r *AuthorRepository) GetAuthors(ctx context.Context, filter domain.AuthorFilter) (domain.AuthorList, error) {
+	db := conn(ctx, r.db)
+
+	var totalItems int32
+	err := db.QueryRowContext(ctx, "SELECT COUNT(author_uuid) FROM authors").Scan(&totalItems)
+	if err != nil {
+		return domain.AuthorList{}, err
+	}
+
+	query := `SELECT ` + authorColumns + `
+		FROM authors aut
+		` + authorArticlesJoin + `
+		GROUP BY aut.author_uuid, aut.name
+		ORDER BY aut.name, aut.author_uuid
+		LIMIT ?2 OFFSET ?3`
+	args := []interface{}{formatTime(time.Now()), filter.PageSize, filter.PageSize * (filter.Page - 1)}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return domain.AuthorList{}, err
+	}
+	defer rows.Close()
+
+	authors := make([]domain.Author, 0)
+	for rows.Next() {
+		author, err := scanAuthor(rows)
+		if err != nil {
+			return domain.AuthorList{}, err
+		}
+		authors = append(authors, author)
+	}
+
+	if rows.Err() != nil {
+		return domain.AuthorList{}, rows.Err()
+	}
+
+	return domain.AuthorList{
+		Authors:    authors,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalItems: totalItems,
+	}, nil
+}
+
+func scanAuthor(row scanner) (domain.Author, error) {
+	var (
+		author          domain.Author
+		latestArticleAt sql.NullString
+	)
+
+	err := row.Scan(
+		&author.UUID,
+		&author.Name,
+		&author.ArticleCount,
+		&latestArticleAt,
+	)
+	if err != nil {
+		return domain.Author{}, err
+	}
+
+	author.LatestArticleAt, err = parseTime(latestArticleAt)
+	if err != nil {
+		return domain.Author{}, err
+	}
+
+	return author, nil
+}
@@ -0,0 +1,158 @@
+// Package sqlite implements the repositories on SQLite, for local development
+// and edge deployments without Postgres. Full-text search uses FTS5, so the
+// driver has to be built with cgo and the sqlite_fts5 build tag.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratesqlite "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// IsDSN reports whether dsn points at a SQLite database, e.g.
+// "sqlite:///var/lib/articles-feed/articles.db".
+func IsDSN(dsn string) bool {
+	return strings.HasPrefix(dsn, "sqlite://") || strings.HasPrefix(dsn, "sqlite3://")
+}
+
+// Open opens the SQLite database of a sqlite:// DSN. Foreign keys are
+// enforced, and transactions take the write lock up front so concurrent
+// writers wait for each other instead of failing.
+func Open(dsn string) (*sql.DB, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sqlite dsn: %w", err)
+	}
+
+	path := u.Host + u.Path
+	if path == "" {
+		return nil, errors.New("invalid sqlite dsn: missing database path")
+	}
+
+	query := u.Query()
+	query.Set("_foreign_keys", "on")
+	query.Set("_txlock", "immediate")
+	if query.Get("_journal_mode") == "" {
+		query.Set("_journal_mode", "WAL")
+	}
+	if query.Get("_busy_timeout") == "" {
+		query.Set("_busy_timeout", "5000")
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+path+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Migrate applies the SQLite migrations found at sourceURL, such as
+// "file://migrations/sqlite".
+func Migrate(db *sql.DB, sourceURL string) error {
+	driver, err := migratesqlite.WithInstance(db, &migratesqlite.Config{})
+	if err != nil {
+		return err
+	}
+
+	m, err := migrate.NewWithDatabaseInstance(sourceURL, "sqlite3", driver)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		return err
+	}
+	return nil
+}
+
+// querier runs statements on either the database or a transaction.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type txKey struct{}
+
+// Transactor runs repository calls as one unit of work.
+type Transactor struct {
+	db *sql.DB
+}
+
+func InitTransactor(db *sql.DB) Transactor {
+	return Transactor{
+		db: db,
+	}
+}
+
+// WithinTransaction runs fn in a transaction that is committed when fn returns
+// nil and rolled back otherwise. Repository calls made with the ctx passed to
+// fn join the transaction, as do nested WithinTransaction calls.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTransaction(ctx, t.db, fn)
+}
+
+func withinTransaction(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// conn returns the transaction ctx carries, or the database outside of one.
+func conn(ctx context.Context, db *sql.DB) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// timeLayout stores timestamps as UTC text with a fixed width, so they sort
+// and compare correctly as strings. Like Postgres, it keeps microseconds.
+const timeLayout = "2006-01-02T15:04:05.000000Z"
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// nullTime stores the zero time as NULL.
+func nullTime(t time.Time) sql.NullString {
+	if t.IsZero() {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: formatTime(t), Valid: true}
+}
+
+// parseTime reads a stored timestamp, mapping NULL to the zero time.
+func parseTime(s sql.NullString) (time.Time, error) {
+	if !s.Valid {
+		return time.Time{}, nil
+	}
+	return time.Parse(timeLayout, s.String)
+}
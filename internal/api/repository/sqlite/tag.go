@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+)
+
+type TagRepository struct {
+	db *sql.DB
+}
+
+func InitTagRepository(db *sql.DB) TagRepository {
+	return TagRepository{
+		db: db,
+	}
+}
+
+// GetTags returns the tags of listed articles with their article counts,
+// most used first. A limit of zero returns every tag.
+func (r *TagRepository) GetTags(ctx context.Context, limit int32) ([]domain.Tag, error) {
+	db := conn(ctx, r.db)
+
+	// a negative LIMIT means no limit in SQLite
+	query := `SELECT t.name, COUNT(art.article_uuid)
+		FROM tags t
+		JOIN article_tags at ON at.tag_id = t.id
+		JOIN articles art ON art.article_uuid = at.article_uuid
+		WHERE art.deleted_at IS NULL AND art.status IN ('published', 'scheduled') AND art.publish_at <= ?1
+		GROUP BY t.name
+		ORDER BY COUNT(art.article_uuid) DESC, t.name
+		LIMIT coalesce(nullif(?2, 0), -1)`
+	args := []interface{}{formatTime(time.Now()), limit}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]domain.Tag, 0)
+	for rows.Next() {
+		tag := domain.Tag{}
+
+		err := rows.Scan(
+			&tag.Name,
+			&tag.ArticleCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TagRepository struct {
+	dbpool *pgxpool.Pool
+}
+
+func InitTagRepository(dbpool *pgxpool.Pool) TagRepository {
+	return TagRepository{
+		dbpool: dbpool,
+	}
+}
+
+// GetTags returns the tags of listed articles with their article counts,
+// most used first. A limit of zero returns every tag.
+func (r *TagRepository) GetTags(ctx context.Context, limit int32) ([]domain.Tag, error) {
+	db := conn(ctx, r.dbpool)
+
+	query := `SELECT t.name, COUNT(art.article_uuid)
+		FROM tags t
+		JOIN article_tags at ON at.tag_id = t.id
+		JOIN articles art ON art.article_uuid = at.article_uuid
+		WHERE art.deleted_at IS NULL AND art.status IN ('published', 'scheduled') AND art.publish_at <= now()
+		GROUP BY t.name
+		ORDER BY COUNT(art.article_uuid) DESC, t.name
+		LIMIT NULLIF($1, 0)`
+	args := []interface{}{limit}
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]domain.Tag, 0)
+	for rows.Next() {
+		tag := domain.Tag{}
+
+		err := rows.Scan(
+			&tag.Name,
+			&tag.ArticleCount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return tags, nil
+}
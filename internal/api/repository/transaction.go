@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+// Transactor runs repository calls as one unit of work.
+type Transactor struct {
+	dbpool *pgxpool.Pool
+}
+
+func InitTransactor(dbpool *pgxpool.Pool) Transactor {
+	return Transactor{
+		dbpool: dbpool,
+	}
+}
+
+// WithinTransaction runs fn in a transaction that is committed when fn returns
+// nil and rolled back otherwise. Repository calls made with the ctx passed to
+// fn join the transaction, as do nested WithinTransaction calls.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.dbpool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// conn returns the transaction ctx carries, or the pool outside of one.
+func conn(ctx context.Context, dbpool *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return dbpool
+}
@@ -3,56 +3,236 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/ariefsibuea/articles-feed/internal/api/domain"
-	"github.com/ariefsibuea/articles-feed/internal/api/repository"
 	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
+	"github.com/ariefsibuea/articles-feed/internal/pkg/readpref"
 )
 
 type ArticleUseCase struct {
-	articleRepository repository.ArticleRepository
-	authorRepository  repository.AuthorRepository
+	articleRepository ArticleRepository
+	authorRepository  AuthorRepository
+	transactor        Transactor
+	listCache         ArticleListCache
 }
 
-func InitArticleUseCase(articleRepository repository.ArticleRepository, authorRepository repository.AuthorRepository) ArticleUseCase {
+// InitArticleUseCase returns an ArticleUseCase caching listings in listCache,
+// or not caching them at all if listCache is nil.
+func InitArticleUseCase(articleRepository ArticleRepository, authorRepository AuthorRepository, transactor Transactor, listCache ArticleListCache) ArticleUseCase {
 	return ArticleUseCase{
 		articleRepository: articleRepository,
 		authorRepository:  authorRepository,
+		transactor:        transactor,
+		listCache:         listCache,
 	}
 }
 
 func (u *ArticleUseCase) Create(ctx context.Context, article domain.Article) (domain.Article, error) {
-	author, err := u.authorRepository.GetByName(ctx, article.AuthorName)
-	if err != nil && !errors.Is(err, _errors.ErrAuthorNotFound) {
+	article.CreatedAt = timestampNow()
+
+	status := article.Status
+	if status == "" {
+		status = domain.ArticleStatusPublished
+		if article.PublishAt.After(article.CreatedAt) {
+			status = domain.ArticleStatusScheduled
+		}
+	}
+	if err := applyArticleStatus(&article, status, article.PublishAt, article.CreatedAt); err != nil {
+		return domain.Article{}, err
+	}
+
+	// a new author is only kept if the article referencing it is created
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		author, err := u.resolveAuthor(ctx, article.AuthorName)
+		if err != nil {
+			return err
+		}
+
+		article.AuthorUUID = author.UUID
+		article.AuthorName = author.Name
+
+		article.UUID, err = u.articleRepository.Create(ctx, article)
+		return err
+	})
+	if err != nil {
 		return domain.Article{}, err
 	}
 
-	article.AuthorUUID = author.UUID
+	u.invalidateListCache(ctx)
+
+	article.UpdatedAt = article.CreatedAt
+	article.Version = 1
+	return article, nil
+}
+
+// GetArticles serves listings from the list cache when one is set. A cached
+// listing is marked Cached. Reads that must see the primary skip the cache,
+// as it may hold a listing read from a replica that is behind.
+func (u *ArticleUseCase) GetArticles(ctx context.Context, filter domain.ArticleFilter) (domain.ArticleList, error) {
+	if u.listCache == nil {
+		return u.articleRepository.GetArticles(ctx, filter)
+	}
+
+	cacheKey, err := articleListCacheKey(filter)
+	if err != nil {
+		return domain.ArticleList{}, err
+	}
 
-	if author.UUID == "" {
-		newAuthor := domain.Author{
-			Name: article.AuthorName,
+	if !readpref.Primary(ctx) {
+		if articleList, ok := u.listCache.Get(ctx, cacheKey); ok {
+			articleList.Cached = true
+			return articleList, nil
 		}
+	}
+
+	// a write made while the listing is read invalidates the cache before the
+	// listing is set, so the generation tells that it may be stale
+	generation := u.listCache.Generation(ctx)
+	articleList, err := u.articleRepository.GetArticles(ctx, filter)
+	if err != nil {
+		return domain.ArticleList{}, err
+	}
+
+	u.listCache.Set(ctx, cacheKey, articleList, generation)
+	return articleList, nil
+}
+
+func (u *ArticleUseCase) GetByUUID(ctx context.Context, articleUUID string) (domain.Article, error) {
+	return u.articleRepository.GetByUUID(ctx, articleUUID)
+}
+
+func (u *ArticleUseCase) Delete(ctx context.Context, articleUUID string) error {
+	if err := u.articleRepository.Delete(ctx, articleUUID, timestampNow()); err != nil {
+		return err
+	}
+
+	u.invalidateListCache(ctx)
+	return nil
+}
+
+func (u *ArticleUseCase) Restore(ctx context.Context, articleUUID string) (domain.Article, error) {
+	if err := u.articleRepository.Restore(ctx, articleUUID); err != nil {
+		return domain.Article{}, err
+	}
+
+	u.invalidateListCache(ctx)
 
-		authorUUID, err := u.authorRepository.Create(ctx, newAuthor)
+	return u.articleRepository.GetByUUID(ctx, articleUUID)
+}
+
+// Update replaces title, body and author of an existing article. A non-zero
+// expectedVersion guards against overwriting a concurrent change.
+func (u *ArticleUseCase) Update(ctx context.Context, article domain.Article, expectedVersion int32) (domain.Article, error) {
+	existing, err := u.articleRepository.GetByUUID(ctx, article.UUID)
+	if err != nil {
+		return domain.Article{}, err
+	}
+
+	if expectedVersion != 0 && expectedVersion != existing.Version {
+		return domain.Article{}, _errors.ErrArticleVersionConflict
+	}
+
+	existing.Title = article.Title
+	existing.Body = article.Body
+	existing.AuthorName = article.AuthorName
+
+	return u.save(ctx, existing, expectedVersion)
+}
+
+// Patch applies the non-nil fields of patch to an existing article.
+func (u *ArticleUseCase) Patch(ctx context.Context, articleUUID string, patch domain.ArticlePatch, expectedVersion int32) (domain.Article, error) {
+	existing, err := u.articleRepository.GetByUUID(ctx, articleUUID)
+	if err != nil {
+		return domain.Article{}, err
+	}
+
+	if expectedVersion != 0 && expectedVersion != existing.Version {
+		return domain.Article{}, _errors.ErrArticleVersionConflict
+	}
+
+	if patch.Title != nil {
+		if strings.TrimSpace(*patch.Title) == "" {
+			return domain.Article{}, _errors.BadRequestErrorf("'title' must not be empty")
+		}
+		existing.Title = *patch.Title
+	}
+	if patch.AuthorName != nil {
+		if strings.TrimSpace(*patch.AuthorName) == "" {
+			return domain.Article{}, _errors.BadRequestErrorf("'authorName' must not be empty")
+		}
+		existing.AuthorName = *patch.AuthorName
+	}
+	if patch.Body != nil {
+		existing.Body = *patch.Body
+	}
+
+	// the version read above is what the patch was applied against, so it is
+	// always checked on write even if the client sent no precondition
+	return u.save(ctx, existing, existing.Version)
+}
+
+func (u *ArticleUseCase) save(ctx context.Context, article domain.Article, expectedVersion int32) (domain.Article, error) {
+	article.UpdatedAt = timestampNow()
+
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		author, err := u.resolveAuthor(ctx, article.AuthorName)
 		if err != nil {
-			return domain.Article{}, err
+			return err
 		}
 
-		article.AuthorUUID = authorUUID
-	}
+		article.AuthorUUID = author.UUID
+		article.AuthorName = author.Name
 
-	article.CreatedAt = time.Now().In(time.UTC)
-	newArticleUUID, err := u.articleRepository.Create(ctx, article)
+		article.Version, err = u.articleRepository.Update(ctx, article, expectedVersion)
+		return err
+	})
 	if err != nil {
 		return domain.Article{}, err
 	}
 
-	article.UUID = newArticleUUID
+	u.invalidateListCache(ctx)
 	return article, nil
 }
 
-func (u *ArticleUseCase) GetArticles(ctx context.Context, filter domain.ArticleFilter) (domain.ArticleList, error) {
-	return u.articleRepository.GetArticles(ctx, filter)
+// invalidateListCache drops cached listings after a write, as any of them
+// may now be stale.
+func (u *ArticleUseCase) invalidateListCache(ctx context.Context) {
+	if u.listCache != nil {
+		u.listCache.Invalidate(ctx)
+	}
+}
+
+// timestampNow is the current time at the microsecond precision the
+// repositories store, so an article returned by a write renders the same as
+// when it is read back.
+func timestampNow() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// resolveAuthor looks the author up first, so the common case of a known
+// author does not write, and falls back to an upsert that is safe against
+// concurrent requests creating the same author. The author is returned as
+// stored, so an article returned by a write names it as when read back.
+func (u *ArticleUseCase) resolveAuthor(ctx context.Context, authorName string) (domain.Author, error) {
+	author, err := u.authorRepository.GetByName(ctx, authorName)
+	if err != nil && !errors.Is(err, _errors.ErrAuthorNotFound) {
+		return domain.Author{}, err
+	}
+
+	if author.UUID != "" {
+		return author, nil
+	}
+
+	newAuthor := domain.Author{
+		Name: authorName,
+	}
+
+	// a concurrent request may have stored the name spelled differently
+	if _, err := u.authorRepository.Upsert(ctx, newAuthor); err != nil {
+		return domain.Author{}, err
+	}
+
+	return u.authorRepository.GetByName(ctx, authorName)
 }
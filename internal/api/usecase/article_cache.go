@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+	"github.com/ariefsibuea/articles-feed/internal/pkg/cache"
+)
+
+// ArticleListCache holds article listings by the normalized filter they were
+// fetched with. A backend shared between instances, such as Redis, should
+// report its failures as a miss rather than failing the listing.
+type ArticleListCache interface {
+	Get(ctx context.Context, key string) (domain.ArticleList, bool)
+	// Generation changes on every Invalidate.
+	Generation(ctx context.Context) uint64
+	// Set stores articleList unless the cache was invalidated since
+	// generation was read, as the listing may then have been read before the
+	// write that invalidated it.
+	Set(ctx context.Context, key string, articleList domain.ArticleList, generation uint64)
+	// Invalidate drops every cached listing.
+	Invalidate(ctx context.Context)
+}
+
+// LRUArticleListCache is the in-process ArticleListCache. Each instance of the
+// API only invalidates its own listings.
+type LRUArticleListCache struct {
+	lru *cache.LRU[domain.ArticleList]
+
+	// mu keeps Set from storing a listing between the generation moving on
+	// and the listings being dropped
+	mu         sync.Mutex
+	generation uint64
+}
+
+func InitLRUArticleListCache(size int, ttl time.Duration) LRUArticleListCache {
+	return LRUArticleListCache{
+		lru: cache.NewLRU[domain.ArticleList](size, ttl),
+	}
+}
+
+func (c *LRUArticleListCache) Get(_ context.Context, key string) (domain.ArticleList, bool) {
+	return c.lru.Get(key)
+}
+
+func (c *LRUArticleListCache) Generation(_ context.Context) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generation
+}
+
+func (c *LRUArticleListCache) Set(_ context.Context, key string, articleList domain.ArticleList, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	c.lru.Set(key, articleList)
+}
+
+func (c *LRUArticleListCache) Invalidate(_ context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.lru.Clear()
+}
+
+// articleListCacheKey normalizes filter so that requests listing the same
+// articles share a cache entry.
+func articleListCacheKey(filter domain.ArticleFilter) (string, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	filter.AuthorName = strings.TrimSpace(filter.AuthorName)
+
+	filter.Tags = slices.Clone(filter.Tags)
+	slices.Sort(filter.Tags)
+	filter.Tags = slices.Compact(filter.Tags)
+
+	filter.Facets = slices.Clone(filter.Facets)
+	slices.Sort(filter.Facets)
+	filter.Facets = slices.Compact(filter.Facets)
+
+	if !filter.CreatedFrom.IsZero() {
+		filter.CreatedFrom = filter.CreatedFrom.UTC()
+	}
+	if !filter.CreatedTo.IsZero() {
+		filter.CreatedTo = filter.CreatedTo.UTC()
+	}
+
+	key, err := json.Marshal(filter)
+	if err != nil {
+		return "", err
+	}
+
+	return "articles:" + string(key), nil
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+func (u *ArticleUseCase) GetRevisions(ctx context.Context, articleUUID string) ([]domain.ArticleRevision, error) {
+	if _, err := u.articleRepository.GetByUUID(ctx, articleUUID); err != nil {
+		return nil, err
+	}
+
+	return u.articleRepository.GetRevisions(ctx, articleUUID)
+}
+
+func (u *ArticleUseCase) GetRevision(ctx context.Context, articleUUID string, revision int32) (domain.ArticleRevision, error) {
+	if _, err := u.articleRepository.GetByUUID(ctx, articleUUID); err != nil {
+		return domain.ArticleRevision{}, err
+	}
+
+	return u.articleRepository.GetRevision(ctx, articleUUID, revision)
+}
+
+// RevertToRevision writes the snapshot of an older revision back as a new
+// revision, so the history stays append-only.
+func (u *ArticleUseCase) RevertToRevision(ctx context.Context, articleUUID string, revision int32, expectedVersion int32) (domain.Article, error) {
+	article, err := u.articleRepository.GetByUUID(ctx, articleUUID)
+	if err != nil {
+		return domain.Article{}, err
+	}
+
+	articleRevision, err := u.articleRepository.GetRevision(ctx, articleUUID, revision)
+	if err != nil {
+		return domain.Article{}, err
+	}
+
+	article.Title = articleRevision.Title
+	article.Body = articleRevision.Body
+	article.AuthorName = articleRevision.AuthorName
+
+	return u.save(ctx, article, expectedVersion)
+}
+
+// DiffRevisions returns a line-based unified diff of the body between two
+// revisions of an article.
+func (u *ArticleUseCase) DiffRevisions(ctx context.Context, articleUUID string, fromRevision, toRevision int32) (domain.ArticleRevisionDiff, error) {
+	from, err := u.GetRevision(ctx, articleUUID, fromRevision)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+
+	to, err := u.articleRepository.GetRevision(ctx, articleUUID, toRevision)
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from.Body),
+		B:        difflib.SplitLines(to.Body),
+		FromFile: fmt.Sprintf("revision %d", from.Revision),
+		ToFile:   fmt.Sprintf("revision %d", to.Revision),
+		Context:  3,
+	})
+	if err != nil {
+		return domain.ArticleRevisionDiff{}, err
+	}
+
+	return domain.ArticleRevisionDiff{
+		ArticleUUID:  articleUUID,
+		FromRevision: from.Revision,
+		ToRevision:   to.Revision,
+		Diff:         diff,
+	}, nil
+}
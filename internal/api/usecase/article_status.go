@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+	_errors "github.com/ariefsibuea/articles-feed/internal/pkg/errors"
+)
+
+// articleStatusTransitions lists, for every status, the statuses an article
+// may move to next.
+var articleStatusTransitions = map[domain.ArticleStatus][]domain.ArticleStatus{
+	domain.ArticleStatusDraft:     {domain.ArticleStatusScheduled, domain.ArticleStatusPublished, domain.ArticleStatusArchived},
+	domain.ArticleStatusScheduled: {domain.ArticleStatusDraft, domain.ArticleStatusPublished, domain.ArticleStatusArchived},
+	domain.ArticleStatusPublished: {domain.ArticleStatusArchived},
+	domain.ArticleStatusArchived:  {domain.ArticleStatusDraft, domain.ArticleStatusPublished},
+}
+
+func canTransition(from, to domain.ArticleStatus) bool {
+	for _, next := range articleStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// applyArticleStatus sets status and publish time on the article, enforcing
+// that a scheduled article has a publish time in the future.
+func applyArticleStatus(article *domain.Article, status domain.ArticleStatus, publishAt time.Time, now time.Time) error {
+	if !status.IsValid() {
+		return _errors.BadRequestErrorf("invalid status '%s'", status)
+	}
+
+	switch status {
+	case domain.ArticleStatusScheduled:
+		if !publishAt.After(now) {
+			return _errors.BadRequestErrorf("'publishAt' must be in the future for a scheduled article")
+		}
+		article.PublishAt = publishAt
+	case domain.ArticleStatusPublished:
+		if publishAt.After(now) {
+			return _errors.BadRequestErrorf("'publishAt' is in the future, use status 'scheduled' instead")
+		}
+		article.PublishAt = now
+		if !publishAt.IsZero() {
+			article.PublishAt = publishAt
+		}
+	case domain.ArticleStatusDraft:
+		article.PublishAt = time.Time{}
+	}
+
+	article.Status = status
+	return nil
+}
+
+func (u *ArticleUseCase) Transition(ctx context.Context, articleUUID string, status domain.ArticleStatus, publishAt time.Time) (domain.Article, error) {
+	article, err := u.articleRepository.GetByUUID(ctx, articleUUID)
+	if err != nil {
+		return domain.Article{}, err
+	}
+
+	fromStatus := article.Status
+	if !canTransition(fromStatus, status) {
+		return domain.Article{}, _errors.ConflictErrorf("cannot move article from '%s' to '%s'", fromStatus, status)
+	}
+
+	now := timestampNow()
+	if err := applyArticleStatus(&article, status, publishAt, now); err != nil {
+		return domain.Article{}, err
+	}
+	article.UpdatedAt = now
+
+	version, err := u.articleRepository.UpdateStatus(ctx, article, fromStatus)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	article.Version = version
+
+	u.invalidateListCache(ctx)
+	return article, nil
+}
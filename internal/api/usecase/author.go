@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+)
+
+type AuthorUseCase struct {
+	authorRepository  AuthorRepository
+	articleRepository ArticleRepository
+}
+
+func InitAuthorUseCase(authorRepository AuthorRepository, articleRepository ArticleRepository) AuthorUseCase {
+	return AuthorUseCase{
+		authorRepository:  authorRepository,
+		articleRepository: articleRepository,
+	}
+}
+
+func (u *AuthorUseCase) GetAuthors(ctx context.Context, filter domain.AuthorFilter) (domain.AuthorList, error) {
+	return u.authorRepository.GetAuthors(ctx, filter)
+}
+
+func (u *AuthorUseCase) GetByUUID(ctx context.Context, authorUUID string) (domain.Author, error) {
+	return u.authorRepository.GetByUUID(ctx, authorUUID)
+}
+
+// GetArticles lists the articles of an author, so an unknown author is a not
+// found error rather than an empty page.
+func (u *AuthorUseCase) GetArticles(ctx context.Context, authorUUID string, filter domain.ArticleFilter) (domain.Author, domain.ArticleList, error) {
+	author, err := u.authorRepository.GetByUUID(ctx, authorUUID)
+	if err != nil {
+		return domain.Author{}, domain.ArticleList{}, err
+	}
+
+	filter.AuthorUUID = author.UUID
+
+	articleList, err := u.articleRepository.GetArticles(ctx, filter)
+	if err != nil {
+		return domain.Author{}, domain.ArticleList{}, err
+	}
+
+	return author, articleList, nil
+}
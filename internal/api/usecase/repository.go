@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+)
+
+// ArticleRepository stores articles along with their revisions.
+type ArticleRepository interface {
+	Create(ctx context.Context, article domain.Article) (string, error)
+	GetByUUID(ctx context.Context, articleUUID string) (domain.Article, error)
+	GetArticles(ctx context.Context, filter domain.ArticleFilter) (domain.ArticleList, error)
+	Update(ctx context.Context, article domain.Article, expectedVersion int32) (int32, error)
+	UpdateStatus(ctx context.Context, article domain.Article, fromStatus domain.ArticleStatus) (int32, error)
+	Delete(ctx context.Context, articleUUID string, deletedAt time.Time) error
+	Restore(ctx context.Context, articleUUID string) error
+	GetRevisions(ctx context.Context, articleUUID string) ([]domain.ArticleRevision, error)
+	GetRevision(ctx context.Context, articleUUID string, revision int32) (domain.ArticleRevision, error)
+	SuggestTitles(ctx context.Context, prefix string, limit int32) ([]domain.Suggestion, error)
+}
+
+type AuthorRepository interface {
+	Upsert(ctx context.Context, author domain.Author) (string, error)
+	GetByName(ctx context.Context, name string) (domain.Author, error)
+	GetByUUID(ctx context.Context, authorUUID string) (domain.Author, error)
+	GetAuthors(ctx context.Context, filter domain.AuthorFilter) (domain.AuthorList, error)
+	SuggestNames(ctx context.Context, prefix string, limit int32) ([]domain.Suggestion, error)
+}
+
+type TagRepository interface {
+	GetTags(ctx context.Context, limit int32) ([]domain.Tag, error)
+}
+
+// Transactor runs repository calls as one unit of work. Calls made with the
+// ctx passed to fn take part in it.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+	"github.com/ariefsibuea/articles-feed/internal/pkg/cache"
+)
+
+type SuggestionUseCase struct {
+	articleRepository ArticleRepository
+	authorRepository  AuthorRepository
+	cache             *cache.LRU[[]domain.Suggestion]
+}
+
+func InitSuggestionUseCase(articleRepository ArticleRepository, authorRepository AuthorRepository, cacheSize int, cacheTTL time.Duration) SuggestionUseCase {
+	return SuggestionUseCase{
+		articleRepository: articleRepository,
+		authorRepository:  authorRepository,
+		cache:             cache.NewLRU[[]domain.Suggestion](cacheSize, cacheTTL),
+	}
+}
+
+// Suggest returns up to limit titles or author names matching prefix. Results
+// are cached briefly, as the endpoint is called on every keystroke.
+func (u *SuggestionUseCase) Suggest(ctx context.Context, suggestionType domain.SuggestionType, prefix string, limit int32) ([]domain.Suggestion, error) {
+	cacheKey := fmt.Sprintf("%s:%d:%s", suggestionType, limit, strings.ToLower(prefix))
+	if suggestions, ok := u.cache.Get(cacheKey); ok {
+		return suggestions, nil
+	}
+
+	var (
+		suggestions []domain.Suggestion
+		err         error
+	)
+
+	switch suggestionType {
+	case domain.SuggestionTypeAuthor:
+		suggestions, err = u.authorRepository.SuggestNames(ctx, prefix, limit)
+	default:
+		suggestions, err = u.articleRepository.SuggestTitles(ctx, prefix, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	u.cache.Set(cacheKey, suggestions)
+	return suggestions, nil
+}
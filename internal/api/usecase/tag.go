@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+)
+
+type TagUseCase struct {
+	tagRepository TagRepository
+}
+
+func InitTagUseCase(tagRepository TagRepository) TagUseCase {
+	return TagUseCase{
+		tagRepository: tagRepository,
+	}
+}
+
+func (u *TagUseCase) GetTags(ctx context.Context, limit int32) ([]domain.Tag, error) {
+	return u.tagRepository.GetTags(ctx, limit)
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU is an in-process cache holding at most capacity entries, each valid for
+// ttl after it was set. It is safe for concurrent use.
+type LRU[V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type lruEntry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+func NewLRU[V any](capacity int, ttl time.Duration) *LRU[V] {
+	return &LRU[V]{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element, capacity),
+	}
+}
+
+func (c *LRU[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+
+	element, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+
+	entry := element.Value.(*lruEntry[V])
+	if !time.Now().Before(entry.expiresAt) {
+		c.remove(element)
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (c *LRU[V]) Set(key string, value V) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry[V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{
+		key:       key,
+		value:     value,
+		expiresAt: expiresAt,
+	})
+
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *LRU[V]) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+func (c *LRU[V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.entries = make(map[string]*list.Element, c.capacity)
+}
+
+func (c *LRU[V]) remove(element *list.Element) {
+	entry := c.order.Remove(element).(*lruEntry[V])
+	delete(c.entries, entry.key)
+}
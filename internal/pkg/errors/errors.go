@@ -7,9 +7,11 @@ import (
 )
 
 var (
-	ErrInvalidSearchPath = errors.New("invalid search path")
+	ErrAuthorNotFound          = NotFoundErrorf("author not found")
+	ErrArticleNotFound         = NotFoundErrorf("article not found")
+	ErrArticleRevisionNotFound = NotFoundErrorf("article revision not found")
 
-	ErrAuthorNotFound = NotFoundErrorf("author not found")
+	ErrArticleVersionConflict = ConflictErrorf("article has been modified by another request")
 )
 
 type CustomError interface {
@@ -85,3 +87,63 @@ func NotFoundErrorf(format string, args ...interface{}) CustomError {
 		message:    fmt.Sprintf(format, args...),
 	}
 }
+
+type ConflictError struct {
+	statusCode int
+	message    string
+}
+
+func (e *ConflictError) Code() int {
+	return e.statusCode
+}
+
+func (e *ConflictError) Error() string {
+	return e.message
+}
+
+func ConflictErrorf(format string, args ...interface{}) CustomError {
+	return &ConflictError{
+		statusCode: http.StatusConflict,
+		message:    fmt.Sprintf(format, args...),
+	}
+}
+
+type PreconditionFailedError struct {
+	statusCode int
+	message    string
+}
+
+func (e *PreconditionFailedError) Code() int {
+	return e.statusCode
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return e.message
+}
+
+func PreconditionFailedErrorf(format string, args ...interface{}) CustomError {
+	return &PreconditionFailedError{
+		statusCode: http.StatusPreconditionFailed,
+		message:    fmt.Sprintf(format, args...),
+	}
+}
+
+type NotAcceptableError struct {
+	statusCode int
+	message    string
+}
+
+func (e *NotAcceptableError) Code() int {
+	return e.statusCode
+}
+
+func (e *NotAcceptableError) Error() string {
+	return e.message
+}
+
+func NotAcceptableErrorf(format string, args ...interface{}) CustomError {
+	return &NotAcceptableError{
+		statusCode: http.StatusNotAcceptable,
+		message:    fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,18 @@
+// Package readpref carries, through a request context, that reads have to see
+// the latest writes and so must not be served by a lagging read replica.
+package readpref
+
+import "context"
+
+type primaryKey struct{}
+
+// WithPrimary marks ctx so that reads made with it go to the primary.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// Primary reports whether reads made with ctx have to go to the primary.
+func Primary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
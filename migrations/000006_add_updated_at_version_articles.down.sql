@@ -0,0 +1,4 @@
+set search_path = articles_feed, public;
+
+alter table articles drop column if exists version;
+alter table articles drop column if exists updated_at;
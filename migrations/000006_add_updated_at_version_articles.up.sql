@@ -0,0 +1,6 @@
+set search_path = articles_feed, public;
+
+alter table articles add column if not exists updated_at timestamp with time zone default now();
+alter table articles add column if not exists version integer not null default 1;
+
+update articles set updated_at = created_at;
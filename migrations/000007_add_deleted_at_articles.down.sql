@@ -0,0 +1,5 @@
+set search_path = articles_feed, public;
+
+drop index if exists idx_articles_not_deleted_created_at;
+
+alter table articles drop column if exists deleted_at;
@@ -0,0 +1,5 @@
+set search_path = articles_feed, public;
+
+alter table articles add column if not exists deleted_at timestamp with time zone;
+
+create index if not exists idx_articles_not_deleted_created_at on articles (created_at desc) where deleted_at is null;
@@ -0,0 +1,3 @@
+set search_path = articles_feed, public;
+
+drop table if exists article_revisions;
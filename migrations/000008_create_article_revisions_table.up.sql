@@ -0,0 +1,17 @@
+set search_path = articles_feed, public;
+
+create table if not exists article_revisions (
+	id bigserial primary key,
+	article_uuid uuid not null,
+	revision integer not null,
+	author_uuid uuid,
+	title text not null,
+	body text,
+	created_at timestamp with time zone default now(),
+	unique (article_uuid, revision)
+);
+
+insert into article_revisions (article_uuid, revision, author_uuid, title, body, created_at)
+select article_uuid, version, author_uuid, title, body, updated_at
+from articles
+on conflict (article_uuid, revision) do nothing;
@@ -0,0 +1,7 @@
+set search_path = articles_feed, public;
+
+drop index if exists idx_articles_status_publish_at;
+
+alter table articles drop constraint if exists articles_status_check;
+alter table articles drop column if exists publish_at;
+alter table articles drop column if exists status;
@@ -0,0 +1,11 @@
+set search_path = articles_feed, public;
+
+alter table articles add column if not exists status varchar(16) not null default 'published';
+alter table articles add column if not exists publish_at timestamp with time zone default now();
+
+alter table articles drop constraint if exists articles_status_check;
+alter table articles add constraint articles_status_check check (status in ('draft', 'scheduled', 'published', 'archived'));
+
+update articles set publish_at = created_at;
+
+create index if not exists idx_articles_status_publish_at on articles (status, publish_at) where deleted_at is null;
@@ -0,0 +1,4 @@
+set search_path = articles_feed, public;
+
+drop table if exists article_tags;
+drop table if exists tags;
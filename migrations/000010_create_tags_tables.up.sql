@@ -0,0 +1,14 @@
+set search_path = articles_feed, public;
+
+create table if not exists tags (
+	id bigserial primary key,
+	name varchar(64) unique not null
+);
+
+create table if not exists article_tags (
+	article_uuid uuid not null,
+	tag_id bigint not null references tags (id) on delete cascade,
+	primary key (article_uuid, tag_id)
+);
+
+create index if not exists idx_article_tags_tag_id on article_tags (tag_id);
@@ -0,0 +1,10 @@
+set search_path = articles_feed, public;
+
+create index if not exists idx_articles_fulltext_search on articles using GIN (
+    to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(body, ''))
+);
+
+drop index if exists idx_articles_search_vector;
+
+alter table articles drop column if exists search_vector;
+alter table articles drop column if exists language;
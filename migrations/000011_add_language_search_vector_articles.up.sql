@@ -0,0 +1,18 @@
+set search_path = articles_feed, public;
+
+alter table articles add column if not exists language varchar(16) not null default 'simple';
+
+-- the unstemmed 'simple' lexemes are always kept next to the stemmed ones,
+-- so searches without a language keep matching exactly as before
+alter table articles add column if not exists search_vector tsvector generated always as (
+    to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(body, '')) ||
+    case language
+        when 'en' then to_tsvector('english', coalesce(title, '') || ' ' || coalesce(body, ''))
+        when 'id' then to_tsvector('indonesian', coalesce(title, '') || ' ' || coalesce(body, ''))
+        else ''::tsvector
+    end
+) stored;
+
+create index if not exists idx_articles_search_vector on articles using GIN (search_vector);
+
+drop index if exists idx_articles_fulltext_search;
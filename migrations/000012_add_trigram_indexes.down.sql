@@ -0,0 +1,4 @@
+set search_path = articles_feed, public;
+
+drop index if exists idx_authors_name_trgm;
+drop index if exists idx_articles_title_trgm;
@@ -0,0 +1,6 @@
+create extension if not exists pg_trgm;
+
+set search_path = articles_feed, public;
+
+create index if not exists idx_articles_title_trgm on articles using GIN (title gin_trgm_ops);
+create index if not exists idx_authors_name_trgm on authors using GIN (name gin_trgm_ops);
@@ -0,0 +1,3 @@
+set search_path = articles_feed, public;
+
+drop index if exists idx_authors_normalized_name;
@@ -0,0 +1,28 @@
+set search_path = articles_feed, public;
+
+-- authors sharing a normalized name are merged into the oldest of them
+with duplicates as (
+	select author_uuid, keep_uuid
+	from (
+		select author_uuid, first_value(author_uuid) over (partition by lower(btrim(name)) order by id) as keep_uuid
+		from authors
+	) aut
+	where author_uuid <> keep_uuid
+), moved_articles as (
+	update articles art
+	set author_uuid = dup.keep_uuid
+	from duplicates dup
+	where art.author_uuid = dup.author_uuid
+), moved_revisions as (
+	update article_revisions rev
+	set author_uuid = dup.keep_uuid
+	from duplicates dup
+	where rev.author_uuid = dup.author_uuid
+)
+delete from authors aut
+using duplicates dup
+where aut.author_uuid = dup.author_uuid;
+
+update authors set name = btrim(name) where name <> btrim(name);
+
+create unique index if not exists idx_authors_normalized_name on authors (lower(btrim(name)));
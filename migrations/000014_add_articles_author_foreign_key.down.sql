@@ -0,0 +1,5 @@
+set search_path = articles_feed, public;
+
+drop index if exists idx_articles_author_uuid;
+
+alter table articles drop constraint if exists fk_articles_author_uuid;
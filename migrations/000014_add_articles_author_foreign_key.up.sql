@@ -0,0 +1,12 @@
+set search_path = articles_feed, public;
+
+-- articles pointing at authors that no longer exist lose their author
+update articles art
+set author_uuid = null
+where art.author_uuid is not null
+	and not exists (select 1 from authors aut where aut.author_uuid = art.author_uuid);
+
+alter table articles
+	add constraint fk_articles_author_uuid foreign key (author_uuid) references authors (author_uuid);
+
+create index if not exists idx_articles_author_uuid on articles (author_uuid);
@@ -0,0 +1,5 @@
+drop table if exists article_tags;
+drop table if exists tags;
+drop table if exists article_revisions;
+drop table if exists articles;
+drop table if exists authors;
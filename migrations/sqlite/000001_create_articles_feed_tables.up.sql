@@ -0,0 +1,54 @@
+-- timestamps are stored as fixed-width UTC text, see timeLayout in
+-- internal/api/repository/sqlite, so they compare correctly as strings
+
+create table if not exists authors (
+	id integer primary key autoincrement,
+	author_uuid text unique not null,
+	name text not null check (length(name) <= 255),
+	-- lower-cased, trimmed name, set by the repository as SQLite's lower()
+	-- only folds ASCII
+	normalized_name text unique not null
+);
+
+create table if not exists articles (
+	id integer primary key autoincrement,
+	article_uuid text unique not null,
+	author_uuid text references authors (author_uuid),
+	title text not null,
+	body text not null default '',
+	status text not null default 'published' check (status in ('draft', 'scheduled', 'published', 'archived')),
+	publish_at text,
+	language text not null default 'simple',
+	version integer not null default 1,
+	created_at text not null,
+	updated_at text not null,
+	deleted_at text
+);
+
+create index if not exists idx_articles_created_at on articles (created_at, article_uuid);
+create index if not exists idx_articles_author_uuid on articles (author_uuid);
+create index if not exists idx_articles_status_publish_at on articles (status, publish_at) where deleted_at is null;
+
+create table if not exists article_revisions (
+	id integer primary key autoincrement,
+	article_uuid text not null,
+	revision integer not null,
+	author_uuid text,
+	title text not null,
+	body text not null default '',
+	created_at text not null,
+	unique (article_uuid, revision)
+);
+
+create table if not exists tags (
+	id integer primary key autoincrement,
+	name text unique not null check (length(name) <= 64)
+);
+
+create table if not exists article_tags (
+	article_uuid text not null references articles (article_uuid) on delete cascade,
+	tag_id integer not null references tags (id) on delete cascade,
+	primary key (article_uuid, tag_id)
+);
+
+create index if not exists idx_article_tags_tag_id on article_tags (tag_id);
@@ -0,0 +1,9 @@
+drop trigger if exists authors_fts_update;
+drop trigger if exists authors_fts_delete;
+drop trigger if exists authors_fts_insert;
+drop trigger if exists articles_fts_update;
+drop trigger if exists articles_fts_delete;
+drop trigger if exists articles_fts_insert;
+
+drop table if exists authors_fts;
+drop table if exists articles_fts;
@@ -0,0 +1,42 @@
+-- external content FTS5 indexes over articles and authors, kept in sync by
+-- triggers; the porter tokenizer stems English words
+create virtual table if not exists articles_fts using fts5 (
+	title, body,
+	content = 'articles', content_rowid = 'id',
+	tokenize = 'porter unicode61'
+);
+
+create virtual table if not exists authors_fts using fts5 (
+	name,
+	content = 'authors', content_rowid = 'id',
+	tokenize = 'unicode61'
+);
+
+create trigger if not exists articles_fts_insert after insert on articles begin
+	insert into articles_fts (rowid, title, body) values (new.id, new.title, new.body);
+end;
+
+create trigger if not exists articles_fts_delete after delete on articles begin
+	insert into articles_fts (articles_fts, rowid, title, body) values ('delete', old.id, old.title, old.body);
+end;
+
+create trigger if not exists articles_fts_update after update of title, body on articles begin
+	insert into articles_fts (articles_fts, rowid, title, body) values ('delete', old.id, old.title, old.body);
+	insert into articles_fts (rowid, title, body) values (new.id, new.title, new.body);
+end;
+
+create trigger if not exists authors_fts_insert after insert on authors begin
+	insert into authors_fts (rowid, name) values (new.id, new.name);
+end;
+
+create trigger if not exists authors_fts_delete after delete on authors begin
+	insert into authors_fts (authors_fts, rowid, name) values ('delete', old.id, old.name);
+end;
+
+create trigger if not exists authors_fts_update after update of name on authors begin
+	insert into authors_fts (authors_fts, rowid, name) values ('delete', old.id, old.name);
+	insert into authors_fts (rowid, name) values (new.id, new.name);
+end;
+
+insert into articles_fts (articles_fts) values ('rebuild');
+insert into authors_fts (authors_fts) values ('rebuild');
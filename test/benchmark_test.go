@@ -0,0 +1,83 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+	"github.com/ariefsibuea/articles-feed/internal/api/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/require"
+)
+
+// BenchmarkGetArticles lists the first page of articles with the search_path
+// set once per connection, and with it set before every call as the
+// repositories used to, to show the cost of the extra round trip.
+func BenchmarkGetArticles(b *testing.B) {
+	ctx := context.Background()
+
+	poolConfig, err := testPoolConfig()
+	require.NoError(b, err)
+
+	dbpool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	require.NoError(b, err)
+	defer dbpool.Close()
+
+	sqlDB := stdlib.OpenDBFromPool(dbpool)
+	defer sqlDB.Close()
+	require.NoError(b, migratePostgres(sqlDB))
+
+	_, err = dbpool.Exec(ctx, "TRUNCATE TABLE articles, authors, article_revisions, article_tags, tags RESTART IDENTITY")
+	require.NoError(b, err)
+
+	replicas := repository.InitReplicas(dbpool, nil)
+	articleRepository := repository.InitArticleRepository(dbpool, replicas)
+	authorRepository := repository.InitAuthorRepository(dbpool)
+
+	for i := 0; i < 200; i++ {
+		authorUUID, err := authorRepository.Upsert(ctx, domain.Author{Name: fmt.Sprintf("Author %d", i%20)})
+		require.NoError(b, err)
+
+		_, err = articleRepository.Create(ctx, domain.Article{
+			AuthorUUID: authorUUID,
+			Title:      fmt.Sprintf("Article %d", i),
+			Body:       "Benchmarking the article listing.",
+			Status:     domain.ArticleStatusPublished,
+			PublishAt:  time.Now().UTC(),
+			Language:   domain.LanguageSimple,
+			CreatedAt:  time.Now().UTC(),
+		})
+		require.NoError(b, err)
+	}
+
+	filter := domain.ArticleFilter{
+		Page:     1,
+		PageSize: 20,
+		Sort:     domain.DefaultArticleSort,
+	}
+
+	b.Run("SearchPathOnConnect", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := articleRepository.GetArticles(ctx, filter); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("SearchPathPerCall", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := dbpool.Exec(ctx, "SET search_path to articles_feed, public"); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := articleRepository.GetArticles(ctx, filter); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package test
+
+const (
+	testAdminToken    = "test-admin-token"
+	testPublicBaseURL = "https://articles.example.org"
+)
+
+func articleIDs(getResponse map[string]interface{}) []string {
+	data, _ := getResponse["data"].(map[string]interface{})
+	articles, _ := data["articles"].([]interface{})
+
+	ids := make([]string, 0, len(articles))
+	for _, a := range articles {
+		article, _ := a.(map[string]interface{})
+		id, _ := article["id"].(string)
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,460 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/domain"
+	"github.com/ariefsibuea/articles-feed/internal/api/handler"
+	"github.com/ariefsibuea/articles-feed/internal/api/repository/memory"
+	"github.com/ariefsibuea/articles-feed/internal/api/usecase"
+	"github.com/ariefsibuea/articles-feed/internal/pkg/readpref"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	_suite "github.com/stretchr/testify/suite"
+)
+
+// MemoryTestSuite runs the handlers and usecases against the in-memory
+// repositories, so it needs no database.
+type MemoryTestSuite struct {
+	_suite.Suite
+	store      *memory.Store
+	transactor memory.Transactor
+	echo       *echo.Echo
+}
+
+func TestMemory(t *testing.T) {
+	_suite.Run(t, new(MemoryTestSuite))
+}
+
+func (suite *MemoryTestSuite) SetupTest() {
+	e := echo.New()
+
+	suite.store = memory.InitStore()
+	articleRepository := memory.InitArticleRepository(suite.store)
+	authorRepository := memory.InitAuthorRepository(suite.store)
+	tagRepository := memory.InitTagRepository(suite.store)
+	suite.transactor = memory.InitTransactor(suite.store)
+
+	articleListCache := usecase.InitLRUArticleListCache(128, time.Minute)
+	articleUseCase := usecase.InitArticleUseCase(&articleRepository, &authorRepository, &suite.transactor, &articleListCache)
+	tagUseCase := usecase.InitTagUseCase(&tagRepository)
+	authorUseCase := usecase.InitAuthorUseCase(&authorRepository, &articleRepository)
+	suggestionUseCase := usecase.InitSuggestionUseCase(&articleRepository, &authorRepository, 128, time.Minute)
+
+	handler.InitArticleHandler(e, articleUseCase, testAdminToken, testPublicBaseURL)
+	handler.InitTagHandler(e, tagUseCase)
+	handler.InitSuggestionHandler(e, suggestionUseCase)
+	handler.InitFeedHandler(e, articleUseCase, testPublicBaseURL)
+	handler.InitAuthorHandler(e, authorUseCase, testPublicBaseURL)
+
+	e.HTTPErrorHandler = handler.ErrorHandler()
+	suite.echo = e
+}
+
+func (suite *MemoryTestSuite) TestCreateAndGetArticle() {
+	articleID := suite.createArticle(`{"title": "Async Programming in Go", "authorName": "Evelyn Parker", "body": "Understanding goroutines.", "tags": ["Go"]}`)
+
+	rec := suite.serve(http.MethodGet, "/articles/"+articleID, nil)
+	suite.Require().Equal(http.StatusOK, rec.Code)
+	assert.True(suite.T(), strings.HasPrefix(rec.Header().Get(handler.HeaderETag), `"1.`))
+
+	data := responseData(suite.T(), rec)
+	assert.Equal(suite.T(), "Async Programming in Go", data["title"])
+	assert.Equal(suite.T(), "Evelyn Parker", data["authorName"])
+	assert.Equal(suite.T(), []interface{}{"go"}, data["tags"])
+
+	rec = suite.serve(http.MethodGet, "/articles/00000000-0000-0000-0000-000000000000", nil)
+	assert.Equal(suite.T(), http.StatusNotFound, rec.Code)
+}
+
+func (suite *MemoryTestSuite) TestGetArticles_Pagination() {
+	ids := make([]string, 0, 5)
+	for _, title := range []string{"One", "Two", "Three", "Four", "Five"} {
+		ids = append(ids, suite.createArticle(`{"title": "`+title+`", "authorName": "Alice Smith", "body": "Body"}`))
+		time.Sleep(time.Millisecond)
+	}
+
+	page := suite.getArticles("/articles?page=2&pageSize=2")
+	assert.Equal(suite.T(), []string{ids[2], ids[1]}, articleIDs(page))
+
+	meta, _ := page["meta"].(map[string]interface{})
+	assert.Equal(suite.T(), float64(5), meta["totalItems"])
+
+	nextCursor, _ := meta["nextCursor"].(string)
+	suite.Require().NotEmpty(nextCursor)
+
+	page = suite.getArticles("/articles?pageSize=2&cursor=" + nextCursor)
+	assert.Equal(suite.T(), []string{ids[0]}, articleIDs(page))
+
+	meta, _ = page["meta"].(map[string]interface{})
+	prevCursor, _ := meta["prevCursor"].(string)
+	suite.Require().NotEmpty(prevCursor)
+
+	page = suite.getArticles("/articles?pageSize=2&cursor=" + prevCursor)
+	assert.Equal(suite.T(), []string{ids[2], ids[1]}, articleIDs(page))
+
+	page = suite.getArticles("/articles?sort=title")
+	assert.Equal(suite.T(), []string{ids[4], ids[3], ids[0], ids[2], ids[1]}, articleIDs(page))
+}
+
+func (suite *MemoryTestSuite) TestGetArticles_Filters() {
+	goID := suite.createArticle(`{"title": "Introduction to Go", "authorName": "Alice Smith", "body": "A quick start.", "tags": ["go", "intro"]}`)
+	restID := suite.createArticle(`{"title": "Understanding REST APIs", "authorName": "Bob Johnson", "body": "Learn RESTful services in Go.", "tags": ["api"]}`)
+	suite.createArticle(`{"title": "Draft", "authorName": "Bob Johnson", "body": "Go", "status": "draft"}`)
+
+	assert.ElementsMatch(suite.T(), []string{goID, restID}, articleIDs(suite.getArticles("/articles?query=go")))
+	assert.Equal(suite.T(), []string{restID}, articleIDs(suite.getArticles("/articles?authorName=bob")))
+	assert.ElementsMatch(suite.T(), []string{goID, restID}, articleIDs(suite.getArticles("/articles?tag=intro&tag=api")))
+	assert.Empty(suite.T(), articleIDs(suite.getArticles("/articles?tag=intro&tag=api&tagMode=all")))
+
+	page := suite.getArticles("/articles?facets=author,tag")
+	meta, _ := page["meta"].(map[string]interface{})
+	facets, _ := meta["facets"].(map[string]interface{})
+	assert.Len(suite.T(), facets["author"], 2)
+	assert.Len(suite.T(), facets["tag"], 3)
+}
+
+func (suite *MemoryTestSuite) TestUpdateArticle_Versions() {
+	articleID := suite.createArticle(`{"title": "Async Programming in Go", "authorName": "Evelyn Parker", "body": "Understanding goroutines."}`)
+
+	rec := suite.serve(http.MethodPut, "/articles/"+articleID,
+		strings.NewReader(`{"title": "Concurrency in Go", "authorName": "Evelyn Parker", "body": "Channels."}`),
+		"If-Match", `"1"`)
+	suite.Require().Equal(http.StatusOK, rec.Code)
+	etag := rec.Header().Get(handler.HeaderETag)
+	assert.True(suite.T(), strings.HasPrefix(etag, `"2.`), etag)
+
+	// the ETag of the update is the one the article is read back with
+	rec = suite.serve(http.MethodGet, "/articles/"+articleID, nil, handler.HeaderIfNoneMatch, etag)
+	assert.Equal(suite.T(), http.StatusNotModified, rec.Code)
+
+	// the author is named as stored, not as sent
+	rec = suite.serve(http.MethodPut, "/articles/"+articleID,
+		strings.NewReader(`{"title": "Concurrency in Go", "authorName": " evelyn parker ", "body": "Goroutines."}`),
+		"If-Match", etag)
+	suite.Require().Equal(http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), "Evelyn Parker", responseData(suite.T(), rec)["authorName"])
+	etag = rec.Header().Get(handler.HeaderETag)
+
+	rec = suite.serve(http.MethodGet, "/articles/"+articleID, nil, handler.HeaderIfNoneMatch, etag)
+	assert.Equal(suite.T(), http.StatusNotModified, rec.Code)
+
+	rec = suite.serve(http.MethodPatch, "/articles/"+articleID,
+		strings.NewReader(`{"body": "Stale."}`),
+		"If-Match", `"1"`)
+	assert.Equal(suite.T(), http.StatusPreconditionFailed, rec.Code)
+
+	rec = suite.serve(http.MethodGet, "/articles/"+articleID+"/revisions", nil)
+	suite.Require().Equal(http.StatusOK, rec.Code)
+	revisions, _ := responseData(suite.T(), rec)["revisions"].([]interface{})
+	assert.Len(suite.T(), revisions, 3)
+
+	rec = suite.serve(http.MethodPost, "/articles/"+articleID+"/revisions/1/revert", nil)
+	suite.Require().Equal(http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), "Async Programming in Go", responseData(suite.T(), rec)["title"])
+}
+
+func (suite *MemoryTestSuite) TestDeleteAndRestoreArticle() {
+	articleID := suite.createArticle(`{"title": "Async Programming in Go", "authorName": "Evelyn Parker", "body": "Understanding goroutines."}`)
+
+	rec := suite.serve(http.MethodDelete, "/articles/"+articleID, nil)
+	suite.Require().Equal(http.StatusNoContent, rec.Code)
+
+	assert.Empty(suite.T(), articleIDs(suite.getArticles("/articles")))
+	assert.Equal(suite.T(), http.StatusNotFound, suite.serve(http.MethodGet, "/articles/"+articleID, nil).Code)
+
+	rec = suite.serve(http.MethodGet, "/articles?includeDeleted=true", nil, handler.HeaderAdminToken, testAdminToken)
+	suite.Require().Equal(http.StatusOK, rec.Code)
+
+	rec = suite.serve(http.MethodPost, "/articles/"+articleID+"/restore", nil)
+	suite.Require().Equal(http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), float64(2), responseData(suite.T(), rec)["version"])
+	assert.Equal(suite.T(), []string{articleID}, articleIDs(suite.getArticles("/articles")))
+}
+
+func (suite *MemoryTestSuite) TestAuthorsTagsAndSuggestions() {
+	suite.createArticle(`{"title": "Introduction to Go", "authorName": "Alice Smith", "body": "A quick start.", "tags": ["go"]}`)
+	suite.createArticle(`{"title": "Go Generics", "authorName": " alice smith ", "body": "Type parameters.", "tags": ["go"]}`)
+	suite.createArticle(`{"title": "Understanding REST APIs", "authorName": "Bob Johnson", "body": "Learn RESTful services."}`)
+
+	page := suite.getArticles("/authors")
+	authors, _ := page["data"].(map[string]interface{})["authors"].([]interface{})
+	suite.Require().Len(authors, 2)
+	alice, _ := authors[0].(map[string]interface{})
+	assert.Equal(suite.T(), "Alice Smith", alice["name"])
+	assert.Equal(suite.T(), float64(2), alice["articleCount"])
+
+	page = suite.getArticles("/tags")
+	tags, _ := page["data"].(map[string]interface{})["tags"].([]interface{})
+	suite.Require().Len(tags, 1)
+	assert.Equal(suite.T(), map[string]interface{}{"name": "go", "articleCount": float64(2)}, tags[0])
+
+	page = suite.getArticles("/suggest?q=go")
+	suggestions, _ := page["data"].(map[string]interface{})["suggestions"].([]interface{})
+	suite.Require().NotEmpty(suggestions)
+	first, _ := suggestions[0].(map[string]interface{})
+	assert.Equal(suite.T(), "Go Generics", first["value"])
+}
+
+func (suite *MemoryTestSuite) TestTransactionRollsBack() {
+	authorRepository := memory.InitAuthorRepository(suite.store)
+
+	errAbort := errors.New("abort")
+	err := suite.transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		_, err := authorRepository.Upsert(ctx, domain.Author{Name: "Grace Hopper"})
+		suite.Require().NoError(err)
+		return errAbort
+	})
+	suite.Require().ErrorIs(err, errAbort)
+
+	_, err = authorRepository.GetByName(context.Background(), "Grace Hopper")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *MemoryTestSuite) TestReadYourWrites() {
+	suite.echo.Use(handler.ReadYourWrites(time.Minute))
+	suite.echo.GET("/read-preference", func(c echo.Context) error {
+		return c.String(http.StatusOK, strconv.FormatBool(readpref.Primary(c.Request().Context())))
+	})
+
+	rec := suite.serve(http.MethodGet, "/read-preference", nil)
+	assert.Equal(suite.T(), "false", rec.Body.String())
+
+	rec = suite.serve(http.MethodPost, "/articles", strings.NewReader(`{"title": "Go", "authorName": "Alice Smith", "status": "retracted"}`))
+	suite.Require().Equal(http.StatusBadRequest, rec.Code)
+	assert.Empty(suite.T(), rec.Result().Cookies())
+
+	rec = suite.serve(http.MethodPost, "/articles", strings.NewReader(`{"title": "Go", "authorName": "Alice Smith", "body": "Body"}`))
+	suite.Require().Equal(http.StatusCreated, rec.Code)
+	cookies := rec.Result().Cookies()
+	suite.Require().Len(cookies, 1)
+
+	rec = suite.serve(http.MethodGet, "/read-preference", nil, "Cookie", cookies[0].Name+"="+cookies[0].Value)
+	assert.Equal(suite.T(), "true", rec.Body.String())
+
+	expired := strconv.FormatInt(time.Now().Add(-time.Second).UnixMilli(), 10)
+	rec = suite.serve(http.MethodGet, "/read-preference", nil, "Cookie", cookies[0].Name+"="+expired)
+	assert.Equal(suite.T(), "false", rec.Body.String())
+}
+
+func (suite *MemoryTestSuite) TestGetArticles_Cache() {
+	suite.createArticle(`{"title": "Go", "authorName": "Alice Smith", "body": "Body", "tags": ["go", "db"]}`)
+
+	rec := suite.serve(http.MethodGet, "/articles?tag=go&tag=db", nil)
+	suite.Require().Equal(http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(suite.T(), "MISS", rec.Header().Get(handler.HeaderCache))
+
+	// the same filter in another order shares the cache entry
+	rec = suite.serve(http.MethodGet, "/articles?tag=db&tag=go", nil)
+	assert.Equal(suite.T(), "HIT", rec.Header().Get(handler.HeaderCache))
+
+	rec = suite.serve(http.MethodGet, "/feed.json?tag=db&tag=go", nil)
+	suite.Require().Equal(http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(suite.T(), "MISS", rec.Header().Get(handler.HeaderCache))
+
+	suite.createArticle(`{"title": "Rust", "authorName": "Alice Smith", "body": "Body", "tags": ["go", "db"]}`)
+
+	rec = suite.serve(http.MethodGet, "/articles?tag=go&tag=db", nil)
+	assert.Equal(suite.T(), "MISS", rec.Header().Get(handler.HeaderCache))
+
+	var page map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.Len(suite.T(), articleIDs(page), 2)
+}
+
+func (suite *MemoryTestSuite) TestConditionalGet() {
+	articleID := suite.createArticle(`{"title": "Go", "authorName": "Alice Smith", "body": "Body"}`)
+
+	for _, target := range []string{"/articles", "/feed.rss", "/articles/" + articleID} {
+		rec := suite.serve(http.MethodGet, target, nil)
+		suite.Require().Equal(http.StatusOK, rec.Code, target)
+		etag := rec.Header().Get(handler.HeaderETag)
+		lastModified := rec.Header().Get(echo.HeaderLastModified)
+		suite.Require().NotEmpty(etag, target)
+		suite.Require().NotEmpty(lastModified, target)
+
+		rec = suite.serve(http.MethodGet, target, nil, handler.HeaderIfNoneMatch, `"stale", `+etag)
+		assert.Equal(suite.T(), http.StatusNotModified, rec.Code, target)
+		assert.Empty(suite.T(), rec.Body.String(), target)
+		assert.Equal(suite.T(), etag, rec.Header().Get(handler.HeaderETag), target)
+
+		rec = suite.serve(http.MethodGet, target, nil, echo.HeaderIfModifiedSince, lastModified)
+		assert.Equal(suite.T(), http.StatusNotModified, rec.Code, target)
+
+		// If-None-Match takes precedence over If-Modified-Since
+		rec = suite.serve(http.MethodGet, target, nil, handler.HeaderIfNoneMatch, `"stale"`, echo.HeaderIfModifiedSince, lastModified)
+		assert.Equal(suite.T(), http.StatusOK, rec.Code, target)
+	}
+
+	rec := suite.serve(http.MethodGet, "/articles/"+articleID, nil)
+	etag := rec.Header().Get(handler.HeaderETag)
+
+	// the ETag of GET /articles/:id guards an update
+	rec = suite.serve(http.MethodPut, "/articles/"+articleID, strings.NewReader(`{"title": "Go 2", "authorName": "Alice Smith", "body": "Body"}`),
+		handler.HeaderIfMatch, etag)
+	suite.Require().Equal(http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = suite.serve(http.MethodGet, "/articles/"+articleID, nil, handler.HeaderIfNoneMatch, etag)
+	assert.Equal(suite.T(), http.StatusOK, rec.Code)
+}
+
+func (suite *MemoryTestSuite) TestConditionalGet_ModifiedByDelete() {
+	suite.createArticle(`{"title": "Go", "authorName": "Alice Smith", "body": "Body"}`)
+	articleID := suite.createArticle(`{"title": "Rust", "authorName": "Alice Smith", "body": "Body"}`)
+
+	for _, target := range []string{"/articles", "/feed.rss"} {
+		rec := suite.serve(http.MethodGet, target, nil)
+		suite.Require().Equal(http.StatusOK, rec.Code, target)
+		lastModified := rec.Header().Get(echo.HeaderLastModified)
+		suite.Require().NotEmpty(lastModified, target)
+
+		// HTTP dates only carry seconds
+		time.Sleep(time.Second + 100*time.Millisecond)
+
+		rec = suite.serve(http.MethodDelete, "/articles/"+articleID, nil)
+		suite.Require().Equal(http.StatusNoContent, rec.Code)
+
+		// the deleted article was the newest one listed
+		rec = suite.serve(http.MethodGet, target, nil, echo.HeaderIfModifiedSince, lastModified)
+		assert.Equal(suite.T(), http.StatusOK, rec.Code, target)
+		assert.NotEqual(suite.T(), lastModified, rec.Header().Get(echo.HeaderLastModified), target)
+		lastModified = rec.Header().Get(echo.HeaderLastModified)
+
+		time.Sleep(time.Second + 100*time.Millisecond)
+
+		rec = suite.serve(http.MethodPost, "/articles/"+articleID+"/restore", nil)
+		suite.Require().Equal(http.StatusOK, rec.Code)
+
+		// restoring lists the article again
+		rec = suite.serve(http.MethodGet, target, nil, echo.HeaderIfModifiedSince, lastModified)
+		assert.Equal(suite.T(), http.StatusOK, rec.Code, target)
+	}
+}
+
+func (suite *MemoryTestSuite) TestGetArticles_CacheSkippedWithinStickyWindow() {
+	suite.echo.Use(handler.ReadYourWrites(time.Minute))
+	suite.createArticle(`{"title": "Go", "authorName": "Alice Smith", "body": "Body"}`)
+
+	rec := suite.serve(http.MethodPost, "/articles", strings.NewReader(`{"title": "Rust", "authorName": "Alice Smith", "body": "Body"}`))
+	suite.Require().Equal(http.StatusCreated, rec.Code, rec.Body.String())
+	articleID, _ := responseData(suite.T(), rec)["id"].(string)
+	cookies := rec.Result().Cookies()
+	suite.Require().Len(cookies, 1)
+
+	// another client fills the cache
+	rec = suite.serve(http.MethodGet, "/articles", nil)
+	assert.Equal(suite.T(), "MISS", rec.Header().Get(handler.HeaderCache))
+	rec = suite.serve(http.MethodGet, "/articles", nil)
+	assert.Equal(suite.T(), "HIT", rec.Header().Get(handler.HeaderCache))
+
+	rec = suite.serve(http.MethodGet, "/articles", nil, "Cookie", cookies[0].Name+"="+cookies[0].Value)
+	suite.Require().Equal(http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(suite.T(), "MISS", rec.Header().Get(handler.HeaderCache))
+
+	var page map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &page))
+	assert.Contains(suite.T(), articleIDs(page), articleID)
+}
+
+func (suite *MemoryTestSuite) TestGetArticles_CacheSkipsListingReadBeforeWrite() {
+	articleRepository := memory.InitArticleRepository(suite.store)
+	authorRepository := memory.InitAuthorRepository(suite.store)
+	slowRepository := &slowArticleRepository{
+		ArticleRepository: &articleRepository,
+		started:           make(chan struct{}, 1),
+		release:           make(chan struct{}),
+	}
+
+	articleListCache := usecase.InitLRUArticleListCache(128, time.Minute)
+	articleUseCase := usecase.InitArticleUseCase(slowRepository, &authorRepository, &suite.transactor, &articleListCache)
+	filter := domain.ArticleFilter{Page: 1, PageSize: 10, Sort: domain.DefaultArticleSort}
+
+	readErr := make(chan error)
+	go func() {
+		_, err := articleUseCase.GetArticles(context.Background(), filter)
+		readErr <- err
+	}()
+
+	// the article is created after the listing was read, but before it is
+	// cached
+	<-slowRepository.started
+	_, err := articleUseCase.Create(context.Background(), domain.Article{Title: "Go", AuthorName: "Alice Smith", Body: "Body"})
+	suite.Require().NoError(err)
+	close(slowRepository.release)
+	suite.Require().NoError(<-readErr)
+
+	articleList, err := articleUseCase.GetArticles(context.Background(), filter)
+	suite.Require().NoError(err)
+	assert.False(suite.T(), articleList.Cached)
+	assert.Len(suite.T(), articleList.Articles, 1)
+}
+
+func (suite *MemoryTestSuite) createArticle(payload string) string {
+	rec := suite.serve(http.MethodPost, "/articles", strings.NewReader(payload))
+	suite.Require().Equal(http.StatusCreated, rec.Code, rec.Body.String())
+
+	id, _ := responseData(suite.T(), rec)["id"].(string)
+	return id
+}
+
+func (suite *MemoryTestSuite) getArticles(target string) map[string]interface{} {
+	rec := suite.serve(http.MethodGet, target, nil)
+	suite.Require().Equal(http.StatusOK, rec.Code, rec.Body.String())
+
+	var response map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+	return response
+}
+
+// serve sends a request with the given header name/value pairs.
+func (suite *MemoryTestSuite) serve(method, target string, body io.Reader, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rec := httptest.NewRecorder()
+
+	suite.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func responseData(t *testing.T, rec *httptest.ResponseRecorder) map[string]interface{} {
+	var response map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response body: %v", err)
+	}
+
+	data, _ := response["data"].(map[string]interface{})
+	return data
+}
+
+// slowArticleRepository holds listings back until release is closed, and
+// signals on started once a listing has been read.
+type slowArticleRepository struct {
+	*memory.ArticleRepository
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *slowArticleRepository) GetArticles(ctx context.Context, filter domain.ArticleFilter) (domain.ArticleList, error) {
+	articleList, err := r.ArticleRepository.GetArticles(ctx, filter)
+
+	select {
+	case r.started <- struct{}{}:
+	default:
+	}
+	<-r.release
+
+	return articleList, err
+}
@@ -1,3 +1,5 @@
+//go:build integration
+
 package test
 
 import (
@@ -6,19 +8,36 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"time"
+
+	"github.com/ariefsibuea/articles-feed/internal/api/repository"
+	"github.com/ariefsibuea/articles-feed/internal/api/repository/sqlite"
+	"github.com/ariefsibuea/articles-feed/internal/api/usecase"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/suite"
 )
 
+const (
+	driverPostgres = "postgres"
+	driverSQLite   = "sqlite"
+)
+
+// sqliteTimeLayout mirrors how the sqlite repositories store timestamps.
+const sqliteTimeLayout = "2006-01-02T15:04:05.000000Z"
+
 type ArticlesFeedTestSuite struct {
 	suite.Suite
+	// driver is the backend the suite runs against, set by TEST_DB_DRIVER
+	driver string
 	dbpool *pgxpool.Pool
 	sqlDB  *sql.DB
 	echo   *echo.Echo
@@ -26,54 +45,176 @@ type ArticlesFeedTestSuite struct {
 }
 
 func (suite *ArticlesFeedTestSuite) SetupSuite() {
-	dbHost := getEnv("TEST_DB_HOST", "localhost")
-	dbPort := getEnv("TEST_DB_PORT", "5433")
-	dbUser := getEnv("TEST_DB_USER", "user_articles_feed_test")
-	dbPassword := getEnv("TEST_DB_PASSWORD", "pass_articles_feed_test")
-	dbName := getEnv("TEST_DB_NAME", "articles_feed_test")
+	suite.ctx = context.Background()
+	suite.driver = getEnv("TEST_DB_DRIVER", driverPostgres)
 
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", dbUser, dbPassword, dbHost, dbPort, dbName)
+	if suite.driver == driverSQLite {
+		suite.setupSQLite()
+		return
+	}
 
-	poolConfig, err := pgxpool.ParseConfig(dsn)
+	poolConfig, err := testPoolConfig()
 	suite.Require().NoError(err)
 
-	poolConfig.MaxConns = 5
-	poolConfig.MinConns = 1
-
 	dbpool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	suite.Require().NoError(err)
 
 	suite.dbpool = dbpool
-	suite.ctx = context.Background()
 
 	suite.sqlDB = stdlib.OpenDBFromPool(dbpool)
 
 	suite.migrateDatabase()
 }
 
+// setupSQLite runs the suite on a fresh database file in a temporary
+// directory.
+func (suite *ArticlesFeedTestSuite) setupSQLite() {
+	dsn := "sqlite://" + filepath.Join(suite.T().TempDir(), "articles_feed_test.db")
+
+	db, err := sqlite.Open(dsn)
+	suite.Require().NoError(err)
+
+	suite.sqlDB = db
+
+	err = sqlite.Migrate(db, "file://"+filepath.Join(basePath(), "migrations", "sqlite"))
+	suite.Require().NoError(err, "failed to run migrations")
+}
+
 func (suite *ArticlesFeedTestSuite) TearDownSuite() {
 	suite.Require().NoError(suite.sqlDB.Close(), "failed close sqlDB")
-	suite.dbpool.Close()
+	if suite.dbpool != nil {
+		suite.dbpool.Close()
+	}
 }
 
 func (suite *ArticlesFeedTestSuite) migrateDatabase() {
-	_, b, _, _ := runtime.Caller(0)
-	basepath := filepath.Dir(filepath.Dir(b))
-	migrationPath := "file://" + filepath.Join(basepath, "migrations")
+	suite.Require().NoError(migratePostgres(suite.sqlDB), "failed to run migrations")
+}
 
-	driver, err := postgres.WithInstance(suite.sqlDB, &postgres.Config{})
-	suite.Require().NoError(err, "failed to create postgres driver")
+func migratePostgres(db *sql.DB) error {
+	migrationPath := "file://" + filepath.Join(basePath(), "migrations")
+
+	// the search_path set on connect must not move the migrations table out
+	// of public, where the migrate CLI keeps it
+	driver, err := postgres.WithInstance(db, &postgres.Config{SchemaName: "public"})
+	if err != nil {
+		return err
+	}
 
 	m, err := migrate.NewWithDatabaseInstance(migrationPath, "postgres", driver)
-	suite.Require().NoError(err, "failed to create database instance")
+	if err != nil {
+		return err
+	}
 
-	if err := m.Up(); err != migrate.ErrNoChange {
-		suite.Require().NoError(err, "failed to run migrations")
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		return err
 	}
 
 	sourceErr, dbErr := m.Close()
-	suite.Require().NoError(sourceErr, "failed to close source")
-	suite.Require().NoError(dbErr, "failed to close database")
+	if sourceErr != nil {
+		return sourceErr
+	}
+	return dbErr
+}
+
+// testRepositories are the repositories of the backend under test.
+type testRepositories struct {
+	article    usecase.ArticleRepository
+	author     usecase.AuthorRepository
+	tag        usecase.TagRepository
+	transactor usecase.Transactor
+}
+
+func (suite *ArticlesFeedTestSuite) repositories() testRepositories {
+	if suite.driver == driverSQLite {
+		articleRepository := sqlite.InitArticleRepository(suite.sqlDB)
+		authorRepository := sqlite.InitAuthorRepository(suite.sqlDB)
+		tagRepository := sqlite.InitTagRepository(suite.sqlDB)
+		transactor := sqlite.InitTransactor(suite.sqlDB)
+
+		return testRepositories{&articleRepository, &authorRepository, &tagRepository, &transactor}
+	}
+
+	replicas := repository.InitReplicas(suite.dbpool, nil)
+	articleRepository := repository.InitArticleRepository(suite.dbpool, replicas)
+	authorRepository := repository.InitAuthorRepository(suite.dbpool)
+	tagRepository := repository.InitTagRepository(suite.dbpool)
+	transactor := repository.InitTransactor(suite.dbpool)
+
+	return testRepositories{&articleRepository, &authorRepository, &tagRepository, &transactor}
+}
+
+// skipOnSQLite skips tests of search features only Postgres has.
+func (suite *ArticlesFeedTestSuite) skipOnSQLite(feature string) {
+	if suite.driver == driverSQLite {
+		suite.T().Skipf("%s is not supported by the sqlite backend", feature)
+	}
+}
+
+var placeholderPattern = regexp.MustCompile(`\$(\d+)`)
+
+// exec runs a statement written with Postgres placeholders on either backend.
+func (suite *ArticlesFeedTestSuite) exec(query string, args ...interface{}) error {
+	if suite.driver == driverSQLite {
+		query = placeholderPattern.ReplaceAllString(query, "?$1")
+	}
+	_, err := suite.sqlDB.ExecContext(suite.ctx, query, args...)
+	return err
+}
+
+// dbTime formats t the way the backend under test stores timestamps.
+func (suite *ArticlesFeedTestSuite) dbTime(t time.Time) interface{} {
+	if suite.driver == driverSQLite {
+		return t.UTC().Format(sqliteTimeLayout)
+	}
+	return t
+}
+
+func (suite *ArticlesFeedTestSuite) insertAuthor(authorUUID uuid.UUID, name string) error {
+	query := "INSERT INTO authors (author_uuid, name) VALUES ($1, $2)"
+	if suite.driver == driverSQLite {
+		query = "INSERT INTO authors (author_uuid, name, normalized_name) VALUES ($1, $2, lower($2))"
+	}
+
+	return suite.exec(query, authorUUID.String(), name)
+}
+
+func (suite *ArticlesFeedTestSuite) insertArticle(articleUUID, authorUUID uuid.UUID, title, body string, createdAt time.Time) error {
+	query := "INSERT INTO articles (article_uuid, author_uuid, title, body, created_at) VALUES ($1, $2, $3, $4, $5)"
+	if suite.driver == driverSQLite {
+		query = `INSERT INTO articles (article_uuid, author_uuid, title, body, publish_at, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $5, $5)`
+	}
+
+	return suite.exec(query, articleUUID.String(), authorUUID.String(), title, body, suite.dbTime(createdAt))
+}
+
+// testPoolConfig configures a pool on the test database from the TEST_DB_*
+// variables.
+func testPoolConfig() (*pgxpool.Config, error) {
+	dbHost := getEnv("TEST_DB_HOST", "localhost")
+	dbPort := getEnv("TEST_DB_PORT", "5433")
+	dbUser := getEnv("TEST_DB_USER", "user_articles_feed_test")
+	dbPassword := getEnv("TEST_DB_PASSWORD", "pass_articles_feed_test")
+	dbName := getEnv("TEST_DB_NAME", "articles_feed_test")
+
+	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", dbUser, dbPassword, dbHost, dbPort, dbName)
+
+	poolConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	poolConfig.MaxConns = 5
+	poolConfig.MinConns = 1
+	poolConfig.AfterConnect = repository.SetSearchPath
+
+	return poolConfig, nil
+}
+
+func basePath() string {
+	_, b, _, _ := runtime.Caller(0)
+	return filepath.Dir(filepath.Dir(b))
 }
 
 func getEnv(key, defaultValue string) string {